@@ -29,3 +29,16 @@ do
     curl -X POST $domain -H 'Content-Type: application/json' -d '{"account_id": "ACC-'$var_acc'","type_charge": "DEBIT","amount":'$var_amount',"tenant_id": "TENANT-1"}'
 done
 
+# --------------------Load batch-------------------------
+domain=http://localhost:5002/add/batch
+
+batch=""
+for (( x=0; x<=10; x++ ))
+do
+    genAcc
+    genAmount
+    batch=$batch'{"account_id": "ACC-'$var_acc'","type_charge": "DEBIT","amount":'$var_amount',"tenant_id": "TENANT-1"},'
+done
+echo curl -X POST $domain -H 'Content-Type: application/json' -d '['${batch%,}']'
+curl -X POST $domain -H 'Content-Type: application/json' -d '['${batch%,}']'
+
@@ -1,6 +1,7 @@
 package main
 
 import(
+	"os"
 	"time"
 	"context"
 	
@@ -12,8 +13,17 @@ import(
 	"github.com/go-debit/internal/core/service"
 	"github.com/go-debit/internal/infra/server"
 	"github.com/go-debit/internal/adapter/api"
+	adapter_grpc "github.com/go-debit/internal/adapter/grpc"
+	"github.com/go-debit/internal/adapter/event"
+	"github.com/go-debit/internal/adapter/client"
 	"github.com/go-debit/internal/adapter/database"
+	"github.com/go-debit/internal/adapter/database/migration"
+	"github.com/go-debit/internal/infra/circuitbreaker"
+	"github.com/go-debit/internal/infra/cache"
+	"github.com/go-debit/internal/infra/auth"
+	"github.com/go-debit/internal/infra/metrics"
 	go_core_pg "github.com/eliezerraj/go-core/database/pg"  
+	"github.com/shopspring/decimal"
 )
 
 var(
@@ -21,6 +31,8 @@ var(
 	appServer	model.AppServer
 	databaseConfig go_core_pg.DatabaseConfig
 	databasePGServer go_core_pg.DatabasePGServer
+	defaultCircuitBreakerConfig model.CircuitBreakerConfig
+	circuitBreakerConfig []model.CircuitBreakerConfig
 	childLogger = log.With().Str("component","go-debit").Str("package", "main").Logger()
 )
 
@@ -33,12 +45,41 @@ func init(){
 	configOTEL 		:= configuration.GetOtelEnv()
 	databaseConfig 	:= configuration.GetDatabaseEnv()
 	apiService 	:= configuration.GetEndpointEnv() 
+	moneyConfig := configuration.GetMoneyEnv()
+	outboxConfig := configuration.GetOutboxEnv()
+	pendingFeeConfig := configuration.GetPendingFeeEnv()
+	cacheConfig := configuration.GetCacheEnv()
+	overdraftConfig := configuration.GetOverdraftEnv()
+	debitLimitConfig := configuration.GetDebitLimitEnv()
+	batchConfig := configuration.GetBatchEnv()
+	eventConfig := configuration.GetEventEnv()
+	tenantConfig := configuration.GetTenantEnv()
+	jwtConfig := configuration.GetJwtEnv()
+	healthConfig := configuration.GetHealthEnv()
+	metricsConfig := configuration.GetMetricsEnv()
+	defaultCircuitBreakerConfig, circuitBreakerConfig = configuration.GetCircuitBreakerEnv(apiService)
 
 	appServer.InfoPod = &infoPod
 	appServer.Server = &server
 	appServer.ConfigOTEL = &configOTEL
 	appServer.DatabaseConfig = &databaseConfig
 	appServer.ApiService = apiService
+	appServer.MoneyConfig = &moneyConfig
+	appServer.OutboxConfig = &outboxConfig
+	appServer.PendingFeeConfig = &pendingFeeConfig
+	appServer.CacheConfig = &cacheConfig
+	appServer.OverdraftConfig = &overdraftConfig
+	appServer.DebitLimitConfig = &debitLimitConfig
+	appServer.BatchConfig = &batchConfig
+	appServer.EventConfig = &eventConfig
+	appServer.TenantConfig = &tenantConfig
+	appServer.JwtConfig = &jwtConfig
+	appServer.HealthConfig = &healthConfig
+	appServer.MetricsConfig = &metricsConfig
+	appServer.CircuitBreakerConfig = circuitBreakerConfig
+
+	// keep the amounts as json numbers (the decimal is exact, only the json representation changes)
+	decimal.MarshalJSONWithoutQuotes = true
 }
 
 func main (){
@@ -67,11 +108,94 @@ func main (){
 		break
 	}
 
+	// migrate up|down [steps]|status runs the embedded migrations and exits
+	migrator, err := migration.NewMigrator(&databasePGServer)
+	if err != nil {
+		childLogger.Error().Err(err).Msg("fatal error load migrations aborting")
+		panic(err)
+	}
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		err = runMigrate(context.Background(), migrator, os.Args[2:])
+		if err != nil {
+			childLogger.Error().Err(err).Msg("error migrate")
+			os.Exit(1)
+		}
+		return
+	}
+
+	// refuse to serve when the schema is behind the migrations of the binary
+	err = migrator.CheckVersion(ctx)
+	if err != nil {
+		childLogger.Error().Err(err).Msg("fatal error schema version (run migrate up) aborting")
+		panic(err)
+	}
+
+	// refuse a batch concurrency that could hold all the connections of the pool
+	err = configuration.ValidateBatchConfig(appServer.BatchConfig, int(databasePGServer.GetConnection().Config().MaxConns))
+	if err != nil {
+		childLogger.Error().Err(err).Msg("fatal error batch config aborting")
+		panic(err)
+	}
+
 	// wire	
 	database := database.NewWorkerRepository(&databasePGServer)
-	workerService := service.NewWorkerService(database, appServer.ApiService)
+	circuitBreakers := circuitbreaker.NewCircuitBreakerRegistry(defaultCircuitBreakerConfig, appServer.CircuitBreakerConfig)
+	payFeeCache := cache.NewCache(*appServer.CacheConfig)
+
+	// observe the circuit breakers and the database pool (recorded in the meter provider of the http server)
+	err = metrics.RegisterCircuitBreakers(circuitBreakers)
+	if err != nil {
+		childLogger.Error().Err(err).Msg("error register circuit breaker metrics")
+	}
+	err = metrics.RegisterDatabasePool(databasePGServer.GetConnection())
+	if err != nil {
+		childLogger.Error().Err(err).Msg("error register database pool metrics")
+	}
+
+	// go-account and go-payfee clients (service 01 account, 02 post balance, 03 script, 04 fee, 05 account balance)
+	accountClient := client.NewAccountClient(appServer.ApiService[0], appServer.ApiService[1], appServer.ApiService[4], appServer.HealthConfig.AccountUrl, circuitBreakers)
+	payFeeClient := client.NewPayFeeClient(appServer.ApiService[2], appServer.ApiService[3], appServer.HealthConfig.PayFeeUrl, circuitBreakers)
+
+	// bearer JWT validator (JWT_ENABLED=false leaves the routes without authentication)
+	var jwtValidator *auth.Validator
+	if appServer.JwtConfig.Enabled {
+		jwtValidator, err = auth.NewValidator(ctx, appServer.JwtConfig)
+		if err != nil {
+			childLogger.Error().Err(err).Msg("fatal error load jwks aborting")
+			panic(err)
+		}
+	}
+
+	// domain event publisher (none, memory or kafka)
+	var eventPublisher service.EventPublisher
+	switch appServer.EventConfig.Publisher {
+	case "kafka":
+		kafkaPublisher, err := event.NewKafkaPublisher(appServer.EventConfig)
+		if err != nil {
+			childLogger.Error().Err(err).Msg("fatal error open kafka producer aborting")
+			panic(err)
+		}
+		eventPublisher = kafkaPublisher
+	case "memory":
+		eventPublisher = event.NewMemoryPublisher()
+	}
+
+	workerService := service.NewWorkerService(database, accountClient, payFeeClient, appServer.MoneyConfig, appServer.OverdraftConfig, appServer.DebitLimitConfig, appServer.BatchConfig, circuitBreakers, payFeeCache, appServer.EventConfig, eventPublisher, appServer.HealthConfig)
 	httpRouters := api.NewHttpRouters(workerService)
-	httpServer := server.NewHttpAppServer(appServer.Server)
+	httpServer := server.NewHttpAppServer(appServer.Server, jwtValidator)
+	grpcHandler := adapter_grpc.NewGrpcHandler(workerService)
+	grpcServer := server.NewGrpcAppServer(appServer.Server, appServer.TenantConfig, jwtValidator)
+
+	// start the outbox dispatcher (balance postings to go-account)
+	ctxWorker, cancelWorker := context.WithCancel(context.Background())
+	defer cancelWorker()
+	go workerService.OutboxDispatcher(ctxWorker, appServer.OutboxConfig)
+
+	// start the pending fee worker (fees not charged while go-payfee was unavailable)
+	go workerService.PendingFeeWorker(ctxWorker, appServer.PendingFeeConfig)
+
+	// start grpc server (same worker service of the http server)
+	go grpcServer.StartGrpcAppServer(ctxWorker, grpcHandler)
 
 	// start server
 	httpServer.StartHttpAppServer(ctx, &httpRouters, &appServer)
@@ -0,0 +1,58 @@
+package main
+
+import(
+	"fmt"
+	"context"
+	"strconv"
+
+	"github.com/go-debit/internal/core/erro"
+	"github.com/go-debit/internal/adapter/database/migration"
+)
+
+// About the migrate subcommand: migrate up | migrate down [steps] | migrate status
+func runMigrate(ctx context.Context, migrator *migration.Migrator, args []string) error {
+	childLogger.Info().Str("func","runMigrate").Strs("args", args).Send()
+
+	if len(args) == 0 {
+		return fmt.Errorf("%w: usage migrate up|down [steps]|status", erro.ErrInvalidMigration)
+	}
+
+	switch args[0] {
+	case "up":
+		count, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d migration(s) applied, schema version %d\n", count, migrator.LatestVersion())
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			var err error
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps <= 0 {
+				return fmt.Errorf("%w: steps must be a positive number", erro.ErrInvalidMigration)
+			}
+		}
+		count, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d migration(s) reverted\n", count)
+	case "status":
+		list_schemaMigration, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, schemaMigration := range *list_schemaMigration {
+			applied_at := "pending"
+			if schemaMigration.AppliedAt != nil {
+				applied_at = schemaMigration.AppliedAt.Format("2006-01-02T15:04:05Z07:00")
+			}
+			fmt.Printf("%04d %-40s %s\n", schemaMigration.Version, schemaMigration.Name, applied_at)
+		}
+	default:
+		return fmt.Errorf("%w: usage migrate up|down [steps]|status", erro.ErrInvalidMigration)
+	}
+
+	return nil
+}
@@ -0,0 +1,391 @@
+package api
+
+import (
+	"io"
+	"fmt"
+	"time"
+	"bufio"
+	"strconv"
+	"strings"
+	"net/http"
+	"encoding/csv"
+	"encoding/xml"
+
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/erro"
+	"github.com/gorilla/mux"
+	"github.com/shopspring/decimal"
+)
+
+// About a statement file format, begin is called once before the first row
+type statementWriter interface {
+	contentType() string
+	begin(statementExport *model.StatementExport, currency string) error
+	write(accountStatement *model.AccountStatement, fees []model.AccountStatementFee) error
+	end(statementExport *model.StatementExport) error
+}
+
+func newStatementWriter(format string, w io.Writer) (statementWriter, error) {
+	switch format {
+	case "csv":
+		return &csvStatementWriter{writer: csv.NewWriter(w)}, nil
+	case "ofx":
+		return &ofxStatementWriter{writer: w}, nil
+	case "qif":
+		return &qifStatementWriter{writer: w}, nil
+	default:
+		return nil, erro.ErrInvalidFormat
+	}
+}
+
+// About the id of a transaction in the files (the transaction_id, or the statement id for the old rows)
+func statementFitID(accountStatement *model.AccountStatement) string {
+	if accountStatement.TransactionID != nil && *accountStatement.TransactionID != "" {
+		return *accountStatement.TransactionID
+	}
+	return strconv.Itoa(accountStatement.ID)
+}
+
+// ---------------------------- CSV ----------------------------
+type csvStatementWriter struct {
+	writer	*csv.Writer
+}
+
+func (c *csvStatementWriter) contentType() string {
+	return "text/csv; charset=utf-8"
+}
+
+// About the rows of the csv, type_fee is only informed on the rows of the fees (type FEE)
+func (c *csvStatementWriter) begin(statementExport *model.StatementExport, currency string) error {
+	return c.writer.Write([]string{"id", "transaction_id", "account_id", "charged_at", "type", "type_fee", "currency", "amount", "fk_account_statement_id"})
+}
+
+func (c *csvStatementWriter) write(accountStatement *model.AccountStatement, fees []model.AccountStatementFee) error {
+	err := c.writer.Write([]string{	strconv.Itoa(accountStatement.ID),
+									statementFitID(accountStatement),
+									accountStatement.AccountID,
+									accountStatement.ChargeAt.UTC().Format(time.RFC3339),
+									accountStatement.Type,
+									"",
+									accountStatement.Currency,
+									accountStatement.Amount.String(),
+									""})
+	if err != nil {
+		return err
+	}
+	for _, fee := range fees {
+		err := c.writer.Write([]string{	strconv.Itoa(fee.ID),
+										statementFitID(accountStatement),
+										accountStatement.AccountID,
+										fee.ChargeAt.UTC().Format(time.RFC3339),
+										"FEE",
+										fee.TypeFee,
+										fee.Currency,
+										fee.Amount.String(),
+										strconv.Itoa(fee.FkAccountStatementID)})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *csvStatementWriter) end(statementExport *model.StatementExport) error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+// ---------------------------- OFX 2.x ----------------------------
+type ofxStatementWriter struct {
+	writer	io.Writer
+}
+
+func ofxDate(date time.Time) string {
+	return date.UTC().Format("20060102150405.000") + "[0:GMT]"
+}
+
+func ofxText(text string) string {
+	var escaped strings.Builder
+	xml.EscapeText(&escaped, []byte(text))
+	return escaped.String()
+}
+
+// About the OFX type of a transaction, a reversal (or the reversal of a fee) is a credit
+func ofxTrnType(amount decimal.Decimal, trnType string) string {
+	if amount.IsPositive() {
+		return "CREDIT"
+	}
+	return trnType
+}
+
+func (o *ofxStatementWriter) contentType() string {
+	return "application/x-ofx"
+}
+
+func (o *ofxStatementWriter) begin(statementExport *model.StatementExport, currency string) error {
+	_, err := fmt.Fprintf(o.writer, `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS><DTSERVER>%s</DTSERVER><LANGUAGE>ENG</LANGUAGE></SONRS></SIGNONMSGSRSV1>
+<BANKMSGSRSV1><STMTTRNRS><TRNUID>0</TRNUID><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>
+<STMTRS><CURDEF>%s</CURDEF>
+<BANKACCTFROM><BANKID>go-debit</BANKID><ACCTID>%s</ACCTID><ACCTTYPE>CHECKING</ACCTTYPE></BANKACCTFROM>
+<BANKTRANLIST><DTSTART>%s</DTSTART><DTEND>%s</DTEND>
+`, ofxDate(time.Now()), ofxText(currency), ofxText(statementExport.AccountID), ofxDate(statementExport.DateStart), ofxDate(statementExport.DateEnd))
+	return err
+}
+
+func (o *ofxStatementWriter) write(accountStatement *model.AccountStatement, fees []model.AccountStatementFee) error {
+	_, err := fmt.Fprintf(o.writer, "<STMTTRN><TRNTYPE>%s</TRNTYPE><DTPOSTED>%s</DTPOSTED><TRNAMT>%s</TRNAMT><FITID>%s</FITID><NAME>%s</NAME></STMTTRN>\n",
+							ofxTrnType(accountStatement.Amount, "DEBIT"),
+							ofxDate(accountStatement.ChargeAt),
+							accountStatement.Amount.String(),
+							ofxText(statementFitID(accountStatement)),
+							ofxText(accountStatement.Type))
+	if err != nil {
+		return err
+	}
+	for _, fee := range fees {
+		_, err := fmt.Fprintf(o.writer, "<STMTTRN><TRNTYPE>%s</TRNTYPE><DTPOSTED>%s</DTPOSTED><TRNAMT>%s</TRNAMT><FITID>%s</FITID><NAME>%s</NAME><MEMO>%s</MEMO></STMTTRN>\n",
+							ofxTrnType(fee.Amount, "SRVCHG"),
+							ofxDate(fee.ChargeAt),
+							fee.Amount.String(),
+							ofxText(statementFitID(accountStatement) + "-FEE-" + strconv.Itoa(fee.ID)),
+							ofxText(fee.TypeFee),
+							ofxText("fee of " + statementFitID(accountStatement)))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *ofxStatementWriter) end(statementExport *model.StatementExport) error {
+	balance, dateAsOf := "0", time.Now()
+	if statementExport.Balance != nil {
+		balance = statementExport.Balance.Amount.String()
+		if statementExport.Balance.UpdateAt != nil {
+			dateAsOf = *statementExport.Balance.UpdateAt
+		}
+	}
+	_, err := fmt.Fprintf(o.writer, `</BANKTRANLIST>
+<LEDGERBAL><BALAMT>%s</BALAMT><DTASOF>%s</DTASOF></LEDGERBAL>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>
+`, balance, ofxDate(dateAsOf))
+	return err
+}
+
+// ---------------------------- QIF ----------------------------
+type qifStatementWriter struct {
+	writer	io.Writer
+}
+
+func (q *qifStatementWriter) contentType() string {
+	return "application/qif"
+}
+
+func (q *qifStatementWriter) begin(statementExport *model.StatementExport, currency string) error {
+	_, err := io.WriteString(q.writer, "!Type:Bank\n")
+	return err
+}
+
+func (q *qifStatementWriter) write(accountStatement *model.AccountStatement, fees []model.AccountStatementFee) error {
+	_, err := fmt.Fprintf(q.writer, "D%s\nT%s\nN%s\nP%s\n^\n",
+							accountStatement.ChargeAt.UTC().Format("01/02/2006"),
+							accountStatement.Amount.String(),
+							statementFitID(accountStatement),
+							accountStatement.Type)
+	if err != nil {
+		return err
+	}
+	for _, fee := range fees {
+		_, err := fmt.Fprintf(q.writer, "D%s\nT%s\nP%s\nMfee of %s\n^\n",
+							fee.ChargeAt.UTC().Format("01/02/2006"),
+							fee.Amount.String(),
+							fee.TypeFee,
+							statementFitID(accountStatement))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (q *qifStatementWriter) end(statementExport *model.StatementExport) error {
+	return nil
+}
+
+// About download the statement of an account as a file (csv, ofx or qif)
+// The file is streamed, so an error after the first row only stops the download
+func (h *HttpRouters) ExportStatement(rw http.ResponseWriter, req *http.Request) error {
+	childLogger.Info().Str("func","ExportStatement").Interface("trace-resquest-id", req.Context().Value("trace-request-id")).Send()
+
+	// trace
+	span := tracerProvider.Span(req.Context(), "adapter.api.ExportStatement")
+	defer span.End()
+
+	//parameters
+	vars := mux.Vars(req)
+	statementExport := model.StatementExport{AccountID: vars["account_id"]}
+
+	format := req.URL.Query().Get("format")
+	if format == "" {
+		format = "csv"
+	}
+
+	// period (from/to inclusive, default the last 30 days)
+	now := time.Now().UTC()
+	statementExport.DateEnd = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	statementExport.DateStart = statementExport.DateEnd.AddDate(0, 0, -30)
+	if req.URL.Query().Get("from") != "" {
+		from, err := time.Parse("2006-01-02", req.URL.Query().Get("from"))
+		if err != nil {
+			core_apiError = core_apiError.NewAPIError(erro.ErrInvalidFilter, http.StatusBadRequest)
+			return &core_apiError
+		}
+		statementExport.DateStart = from
+	}
+	if req.URL.Query().Get("to") != "" {
+		to, err := time.Parse("2006-01-02", req.URL.Query().Get("to"))
+		if err != nil {
+			core_apiError = core_apiError.NewAPIError(erro.ErrInvalidFilter, http.StatusBadRequest)
+			return &core_apiError
+		}
+		statementExport.DateEnd = to.AddDate(0, 0, 1)
+	}
+	if !statementExport.DateStart.Before(statementExport.DateEnd) {
+		core_apiError = core_apiError.NewAPIError(erro.ErrInvalidFilter, http.StatusBadRequest)
+		return &core_apiError
+	}
+
+	buffer := bufio.NewWriter(rw)
+	writer, err := newStatementWriter(format, buffer)
+	if err != nil {
+		core_apiError = core_apiError.NewAPIError(err, http.StatusBadRequest)
+		return &core_apiError
+	}
+
+	// the headers are sent with the first row (or at the end when there is no row)
+	started := false
+	begin := func(currency string) error {
+		started = true
+		rw.Header().Set("Content-Type", writer.contentType())
+		rw.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="statement-%s-%s.%s"`, 
+																statementExport.AccountID, 
+																statementExport.DateStart.Format("2006-01-02"), 
+																format))
+		rw.WriteHeader(http.StatusOK)
+		return writer.begin(&statementExport, currency)
+	}
+
+	// call service
+	err = h.workerService.ExportStatement(req.Context(), &statementExport, func(accountStatement *model.AccountStatement, fees []model.AccountStatementFee) error {
+		if !started {
+			currency := statementExport.Currency
+			if currency == "" {
+				currency = accountStatement.Currency
+			}
+			if err := begin(currency); err != nil {
+				return err
+			}
+		}
+		return writer.write(accountStatement, fees)
+	})
+	if err != nil && !started {
+		switch err {
+		case erro.ErrNotFound:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusNotFound)
+		case erro.ErrHTTPForbiden:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusForbidden)
+		case erro.ErrServiceUnavailable:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusServiceUnavailable)
+		case erro.ErrUnauthorized:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusUnauthorized)
+		case erro.ErrBadRequest:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusBadRequest)
+		default:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusInternalServerError)
+		}
+		return &core_apiError
+	}
+	if err != nil {
+		childLogger.Error().Err(err).Msg("error export statement, download interrupted")
+		buffer.Flush()
+		return nil
+	}
+
+	if !started {
+		if err := begin(statementExport.Currency); err != nil {
+			childLogger.Error().Err(err).Send()
+			return nil
+		}
+	}
+	if err := writer.end(&statementExport); err != nil {
+		childLogger.Error().Err(err).Send()
+	}
+	if err := buffer.Flush(); err != nil {
+		childLogger.Error().Err(err).Send()
+	}
+
+	return nil
+}
+
+// About download the ISO 20022 camt.053 statement of an account for a booking day (default yesterday)
+func (h *HttpRouters) Camt053Statement(rw http.ResponseWriter, req *http.Request) error {
+	childLogger.Info().Str("func","Camt053Statement").Interface("trace-resquest-id", req.Context().Value("trace-request-id")).Send()
+
+	// trace
+	span := tracerProvider.Span(req.Context(), "adapter.api.Camt053Statement")
+	defer span.End()
+
+	//parameters
+	vars := mux.Vars(req)
+	varID := vars["account_id"]
+
+	date := time.Now().UTC().AddDate(0, 0, -1)
+	if req.URL.Query().Get("date") != "" {
+		paramDate, err := time.Parse("2006-01-02", req.URL.Query().Get("date"))
+		if err != nil {
+			core_apiError = core_apiError.NewAPIError(erro.ErrInvalidFilter, http.StatusBadRequest)
+			return &core_apiError
+		}
+		date = paramDate
+	}
+
+	// call service
+	res, err := h.workerService.Camt053Statement(req.Context(), varID, date)
+	if err != nil {
+		switch err {
+		case erro.ErrNotFound:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusNotFound)
+		case erro.ErrHTTPForbiden:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusForbidden)
+		case erro.ErrInvalidFilter:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusBadRequest)
+		case erro.ErrServiceUnavailable:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusServiceUnavailable)
+		case erro.ErrUnauthorized:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusUnauthorized)
+		case erro.ErrBadRequest:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusBadRequest)
+		default:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusInternalServerError)
+		}
+		return &core_apiError
+	}
+
+	document, err := xml.MarshalIndent(res, "", "  ")
+	if err != nil {
+		core_apiError = core_apiError.NewAPIError(err, http.StatusInternalServerError)
+		return &core_apiError
+	}
+
+	rw.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	rw.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="camt053-%s-%s.xml"`, varID, date.Format("2006-01-02")))
+	rw.WriteHeader(http.StatusOK)
+	io.WriteString(rw, xml.Header)
+	rw.Write(document)
+
+	return nil
+}
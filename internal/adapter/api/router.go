@@ -3,6 +3,7 @@ package api
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 	"github.com/rs/zerolog/log"
 	"github.com/go-debit/internal/core/service"
 	"github.com/go-debit/internal/core/model"
@@ -11,6 +12,7 @@ import (
 	go_core_tools "github.com/eliezerraj/go-core/tools"
 	"github.com/eliezerraj/go-core/coreJson"
 	"github.com/gorilla/mux"
+	"github.com/shopspring/decimal"
 )
 
 var childLogger = log.With().Str("component", "go-debit").Str("package", "internal.adapter.api").Logger()
@@ -32,12 +34,37 @@ func NewHttpRouters(workerService *service.WorkerService) HttpRouters {
 	}
 }
 
+// About read the pagination query parameters (limit and cursor)
+func paginationParams(req *http.Request) (*model.Pagination, error) {
+	params := req.URL.Query()
+
+	pagination := model.Pagination{}
+	pagination.Cursor = params.Get("cursor")
+
+	if params.Get("limit") != "" {
+		limit, err := strconv.Atoi(params.Get("limit"))
+		if err != nil {
+			return nil, erro.ErrInvalidLimit
+		}
+		pagination.Limit = limit
+	}
+
+	return &pagination, nil
+}
+
+// About the readiness, 503 when a critical dependency is down (the pod stops receiving traffic)
 func (h *HttpRouters) Health(rw http.ResponseWriter, req *http.Request) {
 	childLogger.Info().Str("func","Health").Interface("trace-resquest-id", req.Context().Value("trace-request-id")).Send()
 
-	json.NewEncoder(rw).Encode(model.MessageRouter{Message: "true"})
+	res := h.workerService.Health(req.Context())
+	if res.Status != "UP" {
+		core_json.WriteJSON(rw, http.StatusServiceUnavailable, res)
+		return
+	}
+	core_json.WriteJSON(rw, http.StatusOK, res)
 }
 
+// About the liveness, only the process (the dependencies are not checked)
 func (h *HttpRouters) Live(rw http.ResponseWriter, req *http.Request) {
 	childLogger.Info().Str("func","Live").Interface("trace-resquest-id", req.Context().Value("trace-request-id")).Send()
 
@@ -66,21 +93,93 @@ func (h *HttpRouters) AddDebit(rw http.ResponseWriter, req *http.Request) error
     }
 	defer req.Body.Close()
 
+	// idempotency key (optional)
+	idempotencyKey := req.Header.Get("Idempotency-Key")
+	if len(idempotencyKey) > 255 {
+		core_apiError = core_apiError.NewAPIError(erro.ErrIdempotencyKey, http.StatusBadRequest)
+		return &core_apiError
+	}
+
 	//call service
-	res, err := h.workerService.AddDebit(req.Context(), &debit)
+	res, err := h.workerService.AddDebit(req.Context(), &debit, idempotencyKey)
+	if err != nil {
+		core_apiError = core_apiError.NewAPIError(err, addDebitStatusCode(err))
+		return &core_apiError
+	}
+	
+	return core_json.WriteJSON(rw, http.StatusOK, res)
+}
+
+// About the http status of an AddDebit error (also used by each item of a batch)
+func addDebitStatusCode(err error) int {
+	switch err {
+	case erro.ErrNotFound:
+		return http.StatusNotFound
+	case erro.ErrHTTPForbiden:
+		return http.StatusForbidden
+	case erro.ErrServiceUnavailable:
+		return http.StatusServiceUnavailable
+	case erro.ErrUnauthorized:
+		return http.StatusUnauthorized
+	case erro.ErrBadRequest:
+		return http.StatusBadRequest
+	case erro.ErrTransInvalid:
+		return http.StatusConflict
+	case erro.ErrInvalidAmount:
+		return http.StatusConflict
+	case erro.ErrIdempotencyConflict:
+		return http.StatusConflict
+	case erro.ErrInsufficientFunds, erro.ErrDebitLimitExceeded:
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (h *HttpRouters) AddDebitBatch(rw http.ResponseWriter, req *http.Request) error {
+	childLogger.Info().Str("func","AddDebitBatch").Interface("trace-resquest-id", req.Context().Value("trace-request-id")).Send()
+
+	//trace
+	span := tracerProvider.Span(req.Context(), "adapter.api.AddDebitBatch")
+	defer span.End()
+
+	// prepare body
+	debits := []model.AccountStatement{}
+	err := json.NewDecoder(req.Body).Decode(&debits)
+    if err != nil {
+		core_apiError = core_apiError.NewAPIError(err, http.StatusBadRequest)
+		return &core_apiError
+    }
+	defer req.Body.Close()
+
+	// idempotency key (optional, each item uses the key + ":" + index)
+	idempotencyKey := req.Header.Get("Idempotency-Key")
+	if len(idempotencyKey) > 240 {
+		core_apiError = core_apiError.NewAPIError(erro.ErrIdempotencyKey, http.StatusBadRequest)
+		return &core_apiError
+	}
+
+	//call service
+	res, err := h.workerService.AddDebitBatch(req.Context(), debits, idempotencyKey)
 	if err != nil {
 		switch err {
-		case erro.ErrNotFound:
-			core_apiError = core_apiError.NewAPIError(err, http.StatusNotFound)
-		case erro.ErrTransInvalid:
-			core_apiError = core_apiError.NewAPIError(err, http.StatusConflict)
-		case erro.ErrInvalidAmount:
-			core_apiError = core_apiError.NewAPIError(err, http.StatusConflict)	
+		case erro.ErrInvalidBatch:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusBadRequest)
 		default:
 			core_apiError = core_apiError.NewAPIError(err, http.StatusInternalServerError)
 		}
 		return &core_apiError
 	}
+
+	// per item result
+	for i := range *res {
+		if (*res)[i].Err != nil {
+			(*res)[i].StatusCode = addDebitStatusCode((*res)[i].Err)
+			(*res)[i].Error = (*res)[i].Err.Error()
+		} else {
+			(*res)[i].StatusCode = http.StatusOK
+		}
+	}
 	
 	return core_json.WriteJSON(rw, http.StatusOK, res)
 }
@@ -99,12 +198,62 @@ func (h *HttpRouters) ListDebit(rw http.ResponseWriter, req *http.Request) error
 	debit := model.AccountStatement{}
 	debit.AccountID = varID
 
+	pagination, err := paginationParams(req)
+	if err != nil {
+		core_apiError = core_apiError.NewAPIError(err, http.StatusBadRequest)
+		return &core_apiError
+	}
+
 	// call service
-	res, err := h.workerService.ListDebit(req.Context(), &debit)
+	res, err := h.workerService.ListDebit(req.Context(), &debit, pagination)
 	if err != nil {
 		switch err {
 		case erro.ErrNotFound:
 			core_apiError = core_apiError.NewAPIError(err, http.StatusNotFound)
+		case erro.ErrHTTPForbiden:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusForbidden)
+		case erro.ErrServiceUnavailable:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusServiceUnavailable)
+		case erro.ErrUnauthorized:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusUnauthorized)
+		case erro.ErrBadRequest:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusBadRequest)
+		case erro.ErrInvalidCursor, erro.ErrInvalidLimit:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusBadRequest)
+		default:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusInternalServerError)
+		}
+		return &core_apiError
+	}
+	
+	return core_json.WriteJSON(rw, http.StatusOK, res)
+}
+
+func (h *HttpRouters) GetDebitLimit(rw http.ResponseWriter, req *http.Request) error {
+	childLogger.Info().Str("func","GetDebitLimit").Interface("trace-resquest-id", req.Context().Value("trace-request-id")).Send()
+
+	// trace
+	span := tracerProvider.Span(req.Context(), "adapter.api.GetDebitLimit")
+	defer span.End()
+
+	//parameters
+	vars := mux.Vars(req)
+	varID := vars["account_id"]
+
+	// call service
+	res, err := h.workerService.GetDebitLimit(req.Context(), varID)
+	if err != nil {
+		switch err {
+		case erro.ErrNotFound:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusNotFound)
+		case erro.ErrHTTPForbiden:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusForbidden)
+		case erro.ErrServiceUnavailable:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusServiceUnavailable)
+		case erro.ErrUnauthorized:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusUnauthorized)
+		case erro.ErrBadRequest:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusBadRequest)
 		default:
 			core_apiError = core_apiError.NewAPIError(err, http.StatusInternalServerError)
 		}
@@ -136,17 +285,245 @@ func (h *HttpRouters) ListDebitPerDate(rw http.ResponseWriter, req *http.Request
 	}
 	debit.ChargeAt = *convertDate
 
+	pagination, err := paginationParams(req)
+	if err != nil {
+		core_apiError = core_apiError.NewAPIError(err, http.StatusBadRequest)
+		return &core_apiError
+	}
+
 	//service
-	res, err := h.workerService.ListDebitPerDate(req.Context(), &debit)
+	res, err := h.workerService.ListDebitPerDate(req.Context(), &debit, pagination)
 	if err != nil {
 		switch err {
 		case erro.ErrNotFound:
 			core_apiError = core_apiError.NewAPIError(err, http.StatusNotFound)
+		case erro.ErrHTTPForbiden:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusForbidden)
+		case erro.ErrServiceUnavailable:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusServiceUnavailable)
+		case erro.ErrUnauthorized:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusUnauthorized)
+		case erro.ErrBadRequest:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusBadRequest)
+		case erro.ErrInvalidCursor, erro.ErrInvalidLimit:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusBadRequest)
 		default:
 			core_apiError = core_apiError.NewAPIError(err, http.StatusInternalServerError)
 		}
 		return &core_apiError
 	}
 	
+	return core_json.WriteJSON(rw, http.StatusOK, res)
+}
+
+func (h *HttpRouters) ReverseDebit(rw http.ResponseWriter, req *http.Request) error {
+	childLogger.Info().Str("func","ReverseDebit").Interface("trace-resquest-id", req.Context().Value("trace-request-id")).Send()
+
+	//trace
+	span := tracerProvider.Span(req.Context(), "adapter.api.ReverseDebit")
+	defer span.End()
+
+	//parameters
+	vars := mux.Vars(req)
+	varID := vars["transaction_id"]
+
+	// prepare body (account_id and the optional amount to be reversed)
+	reversal := model.AccountStatement{}
+	err := json.NewDecoder(req.Body).Decode(&reversal)
+    if err != nil {
+		core_apiError = core_apiError.NewAPIError(err, http.StatusBadRequest)
+		return &core_apiError
+    }
+	defer req.Body.Close()
+
+	reversal.TransactionID = &varID
+
+	//call service
+	res, err := h.workerService.ReverseDebit(req.Context(), &reversal)
+	if err != nil {
+		switch err {
+		case erro.ErrNotFound:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusNotFound)
+		case erro.ErrHTTPForbiden:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusForbidden)
+		case erro.ErrServiceUnavailable:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusServiceUnavailable)
+		case erro.ErrUnauthorized:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusUnauthorized)
+		case erro.ErrBadRequest:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusBadRequest)
+		case erro.ErrTransInvalid, erro.ErrInvalidAmount, erro.ErrAlreadyReversed, erro.ErrReversalExceeded:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusConflict)
+		default:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusInternalServerError)
+		}
+		return &core_apiError
+	}
+	
+	return core_json.WriteJSON(rw, http.StatusOK, res)
+}
+
+func (h *HttpRouters) SearchStatement(rw http.ResponseWriter, req *http.Request) error {
+	childLogger.Info().Str("func","SearchStatement").Interface("trace-resquest-id", req.Context().Value("trace-request-id")).Send()
+
+	//Trace
+	span := tracerProvider.Span(req.Context(), "adapter.api.SearchStatement")
+	defer span.End()
+
+	// parameter
+	params := req.URL.Query()
+
+	filter := model.StatementFilter{}
+	filter.AccountID = params.Get("account")
+	filter.Type = params.Get("type_charge")
+	filter.Currency = params.Get("currency")
+	filter.TenantID = params.Get("tenant_id")
+	filter.TransactionID = params.Get("transaction_id")
+	filter.Sort = params.Get("sort")
+	filter.Order = params.Get("order")
+
+	if params.Get("date_start") != "" {
+		convertDate, err := core_tools.ConvertToDate(params.Get("date_start"))
+		if err != nil {
+			core_apiError = core_apiError.NewAPIError(err, http.StatusBadRequest)
+			return &core_apiError
+		}
+		filter.DateStart = convertDate
+	}
+	// the date_end is inclusive (the whole day)
+	if params.Get("date_end") != "" {
+		convertDate, err := core_tools.ConvertToDate(params.Get("date_end"))
+		if err != nil {
+			core_apiError = core_apiError.NewAPIError(err, http.StatusBadRequest)
+			return &core_apiError
+		}
+		dateEnd := convertDate.AddDate(0, 0, 1)
+		filter.DateEnd = &dateEnd
+	}
+	if params.Get("amount_min") != "" {
+		amount, err := decimal.NewFromString(params.Get("amount_min"))
+		if err != nil {
+			core_apiError = core_apiError.NewAPIError(erro.ErrInvalidFilter, http.StatusBadRequest)
+			return &core_apiError
+		}
+		filter.AmountMin = &amount
+	}
+	if params.Get("amount_max") != "" {
+		amount, err := decimal.NewFromString(params.Get("amount_max"))
+		if err != nil {
+			core_apiError = core_apiError.NewAPIError(erro.ErrInvalidFilter, http.StatusBadRequest)
+			return &core_apiError
+		}
+		filter.AmountMax = &amount
+	}
+
+	pagination, err := paginationParams(req)
+	if err != nil {
+		core_apiError = core_apiError.NewAPIError(err, http.StatusBadRequest)
+		return &core_apiError
+	}
+
+	//service
+	res, err := h.workerService.SearchStatement(req.Context(), &filter, pagination)
+	if err != nil {
+		switch err {
+		case erro.ErrNotFound:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusNotFound)
+		case erro.ErrHTTPForbiden:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusForbidden)
+		case erro.ErrServiceUnavailable:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusServiceUnavailable)
+		case erro.ErrUnauthorized:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusUnauthorized)
+		case erro.ErrBadRequest:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusBadRequest)
+		case erro.ErrInvalidFilter, erro.ErrInvalidCursor, erro.ErrInvalidLimit:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusBadRequest)
+		default:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusInternalServerError)
+		}
+		return &core_apiError
+	}
+	
+	return core_json.WriteJSON(rw, http.StatusOK, res)
+}
+
+func (h *HttpRouters) ListCircuitBreaker(rw http.ResponseWriter, req *http.Request) error {
+	childLogger.Info().Str("func","ListCircuitBreaker").Interface("trace-resquest-id", req.Context().Value("trace-request-id")).Send()
+
+	//Trace
+	span := tracerProvider.Span(req.Context(), "adapter.api.ListCircuitBreaker")
+	defer span.End()
+
+	res, err := h.workerService.ListCircuitBreaker(req.Context())
+	if err != nil {
+		switch err {
+		case erro.ErrHTTPForbiden:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusForbidden)
+		default:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusInternalServerError)
+		}
+		return &core_apiError
+	}
+
+	return core_json.WriteJSON(rw, http.StatusOK, res)
+}
+
+func (h *HttpRouters) ChangeCircuitBreaker(rw http.ResponseWriter, req *http.Request) error {
+	childLogger.Info().Str("func","ChangeCircuitBreaker").Interface("trace-resquest-id", req.Context().Value("trace-request-id")).Send()
+
+	//Trace
+	span := tracerProvider.Span(req.Context(), "adapter.api.ChangeCircuitBreaker")
+	defer span.End()
+
+	//parameters
+	vars := mux.Vars(req)
+	varName := vars["name"]
+	varAction := vars["action"]
+
+	res, err := h.workerService.ChangeCircuitBreaker(req.Context(), varName, varAction)
+	if err != nil {
+		switch err {
+		case erro.ErrNotFound:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusNotFound)
+		case erro.ErrInvalidAction:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusBadRequest)
+		case erro.ErrHTTPForbiden:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusForbidden)
+		default:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusInternalServerError)
+		}
+		return &core_apiError
+	}
+
+	return core_json.WriteJSON(rw, http.StatusOK, res)
+}
+
+// About the counters of the payfee cache (showed in /info)
+func (h *HttpRouters) PayFeeCacheStats() model.CacheStats {
+	return h.workerService.PayFeeCacheStats()
+}
+
+func (h *HttpRouters) InvalidatePayFeeCache(rw http.ResponseWriter, req *http.Request) error {
+	childLogger.Info().Str("func","InvalidatePayFeeCache").Interface("trace-resquest-id", req.Context().Value("trace-request-id")).Send()
+
+	//Trace
+	span := tracerProvider.Span(req.Context(), "adapter.api.InvalidatePayFeeCache")
+	defer span.End()
+
+	//parameters (without key all the entries are removed)
+	key := req.URL.Query().Get("key")
+
+	res, err := h.workerService.InvalidatePayFeeCache(req.Context(), key)
+	if err != nil {
+		switch err {
+		case erro.ErrHTTPForbiden:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusForbidden)
+		default:
+			core_apiError = core_apiError.NewAPIError(err, http.StatusInternalServerError)
+		}
+		return &core_apiError
+	}
+
 	return core_json.WriteJSON(rw, http.StatusOK, res)
 }
\ No newline at end of file
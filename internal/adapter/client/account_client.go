@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/infra/circuitbreaker"
+)
+
+type AccountClient struct {
+	httpClient
+	apiAccount			model.ApiService
+	apiPostBalance		model.ApiService
+	apiAccountBalance	model.ApiService
+	postingBreaker		string
+	healthUrl			string
+}
+
+// About create the http client of go-account
+// apiAccount (get {url}/{account_id}), apiPostBalance (post {url}), apiAccountBalance (get {url}/{account_id}) and healthUrl (get, readiness)
+// The postings (retried by the outbox) have their own circuit breaker ({name}-posting), a backlog of postings does not open the breaker of the debits
+func NewAccountClient(	apiAccount model.ApiService,
+						apiPostBalance model.ApiService,
+						apiAccountBalance model.ApiService,
+						healthUrl string,
+						circuitBreakers *circuitbreaker.CircuitBreakerRegistry) *AccountClient {
+	childLogger.Info().Str("func","NewAccountClient").Send()
+
+	return &AccountClient{
+		httpClient: newHttpClient(circuitBreakers),
+		apiAccount: apiAccount,
+		apiPostBalance: apiPostBalance,
+		apiAccountBalance: apiAccountBalance,
+		postingBreaker: apiPostBalance.Name + "-posting",
+		healthUrl: healthUrl,
+	}
+}
+
+// About get an account
+func (a *AccountClient) GetAccount(ctx context.Context, accountID string) (*model.Account, error) {
+	childLogger.Info().Str("func","GetAccount").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Str("account_id", accountID).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "client.GetAccount")
+	defer span.End()
+
+	var account model.Account
+	err := a.call(ctx, a.apiAccount, a.apiAccount.Url + "/" + url.PathEscape(accountID), nil, &account)
+	if err != nil {
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+// About get the balance of an account
+func (a *AccountClient) GetAccountBalance(ctx context.Context, accountID string) (*model.AccountBalance, error) {
+	childLogger.Info().Str("func","GetAccountBalance").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Str("account_id", accountID).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "client.GetAccountBalance")
+	defer span.End()
+
+	var accountBalance model.AccountBalance
+	err := a.call(ctx, a.apiAccountBalance, a.apiAccountBalance.Url + "/" + url.PathEscape(accountID), nil, &accountBalance)
+	if err != nil {
+		return nil, err
+	}
+
+	return &accountBalance, nil
+}
+
+// About post the amount of an account statement to the account balance (with the header Idempotency-Key)
+func (a *AccountClient) PostBalance(ctx context.Context, accountStatement *model.AccountStatement, idempotencyKey string) error {
+	childLogger.Info().Str("func","PostBalance").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Str("idempotency_key", idempotencyKey).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "client.PostBalance")
+	defer span.End()
+
+	ctx = context.WithValue(ctx, "idempotency-key", idempotencyKey)
+	return a.callBreaker(ctx, a.postingBreaker, a.apiPostBalance, a.apiPostBalance.Url, accountStatement, nil)
+}
+
+// About check go-account is reachable (readiness)
+func (a *AccountClient) Ping(ctx context.Context) error {
+	return a.ping(ctx, a.apiAccount, a.healthUrl)
+}
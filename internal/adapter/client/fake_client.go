@@ -0,0 +1,198 @@
+package client
+
+import (
+	"context"
+	"sync"
+
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/erro"
+)
+
+// About a go-account in memory (for the tests of the service)
+type FakeAccountClient struct {
+	mutex		sync.Mutex
+	accounts	map[string]model.Account
+	balances	map[string]model.AccountBalance
+	postings	[]model.AccountStatement
+	postingKeys	map[string]bool
+	err			error
+}
+
+func NewFakeAccountClient() *FakeAccountClient {
+	return &FakeAccountClient{
+		accounts: map[string]model.Account{},
+		balances: map[string]model.AccountBalance{},
+		postingKeys: map[string]bool{},
+	}
+}
+
+// About add (or replace) an account and its balance
+func (f *FakeAccountClient) SetAccount(account model.Account, accountBalance model.AccountBalance) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.accounts[account.AccountID] = account
+	f.balances[account.AccountID] = accountBalance
+}
+
+// About make all the calls fail with the error (nil restores the calls)
+func (f *FakeAccountClient) SetError(err error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.err = err
+}
+
+// About the account statements posted to the balance
+func (f *FakeAccountClient) Postings() []model.AccountStatement {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return append([]model.AccountStatement(nil), f.postings...)
+}
+
+func (f *FakeAccountClient) GetAccount(ctx context.Context, accountID string) (*model.Account, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.err != nil {
+		return nil, f.err
+	}
+	account, ok := f.accounts[accountID]
+	if !ok {
+		return nil, erro.ErrNotFound
+	}
+
+	return &account, nil
+}
+
+func (f *FakeAccountClient) GetAccountBalance(ctx context.Context, accountID string) (*model.AccountBalance, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.err != nil {
+		return nil, f.err
+	}
+	accountBalance, ok := f.balances[accountID]
+	if !ok {
+		return nil, erro.ErrNotFound
+	}
+
+	return &accountBalance, nil
+}
+
+// About add the amount of the statement to the balance of the account
+// A posting with an idempotencyKey already applied is accepted without changing the balance
+func (f *FakeAccountClient) PostBalance(ctx context.Context, accountStatement *model.AccountStatement, idempotencyKey string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.err != nil {
+		return f.err
+	}
+	accountBalance, ok := f.balances[accountStatement.AccountID]
+	if !ok {
+		return erro.ErrNotFound
+	}
+	if idempotencyKey != "" {
+		if f.postingKeys[idempotencyKey] {
+			return nil
+		}
+		f.postingKeys[idempotencyKey] = true
+	}
+
+	accountBalance.Amount = accountBalance.Amount.Add(accountStatement.Amount)
+	f.balances[accountStatement.AccountID] = accountBalance
+	f.postings = append(f.postings, *accountStatement)
+
+	return nil
+}
+
+func (f *FakeAccountClient) Ping(ctx context.Context) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.err
+}
+
+// About a go-payfee in memory (for the tests of the service)
+type FakePayFeeClient struct {
+	mutex		sync.Mutex
+	scripts		map[string]model.Script
+	fees		map[string]model.Fee
+	calls		int
+	err			error
+}
+
+func NewFakePayFeeClient() *FakePayFeeClient {
+	return &FakePayFeeClient{
+		scripts: map[string]model.Script{},
+		fees: map[string]model.Fee{},
+	}
+}
+
+// About add (or replace) a script and its fees
+func (f *FakePayFeeClient) SetScript(script model.Script, fees ...model.Fee) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.scripts[script.Name] = script
+	for _, fee := range fees {
+		f.fees[fee.Name] = fee
+	}
+}
+
+// About make all the calls fail with the error (nil restores the calls)
+func (f *FakePayFeeClient) SetError(err error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.err = err
+}
+
+// About how many calls were made (to check the cache)
+func (f *FakePayFeeClient) Calls() int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.calls
+}
+
+func (f *FakePayFeeClient) GetScript(ctx context.Context, script string) (*model.Script, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.calls = f.calls + 1
+	if f.err != nil {
+		return nil, f.err
+	}
+	res_script, ok := f.scripts[script]
+	if !ok {
+		return nil, erro.ErrNotFound
+	}
+
+	return &res_script, nil
+}
+
+func (f *FakePayFeeClient) GetFee(ctx context.Context, fee string) (*model.Fee, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.calls = f.calls + 1
+	if f.err != nil {
+		return nil, f.err
+	}
+	res_fee, ok := f.fees[fee]
+	if !ok {
+		return nil, erro.ErrNotFound
+	}
+
+	return &res_fee, nil
+}
+
+func (f *FakePayFeeClient) Ping(ctx context.Context) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.err
+}
@@ -0,0 +1,169 @@
+package client
+
+import (
+	"fmt"
+	"time"
+	"bytes"
+	"context"
+	"net/http"
+	"encoding/json"
+	"errors"
+
+	"github.com/rs/zerolog/log"
+	"github.com/sony/gobreaker"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/erro"
+	"github.com/go-debit/internal/infra/circuitbreaker"
+	"github.com/go-debit/internal/infra/metrics"
+	go_core_observ "github.com/eliezerraj/go-core/observability"
+)
+
+var childLogger = log.With().Str("component","go-debit").Str("package","internal.adapter.client").Logger()
+
+var tracerProvider go_core_observ.TracerProvider
+
+// About the http calls shared by the clients, every call goes through the circuit breaker of the service name
+type httpClient struct {
+	client			*http.Client
+	circuitBreakers	*circuitbreaker.CircuitBreakerRegistry
+}
+
+func newHttpClient(circuitBreakers *circuitbreaker.CircuitBreakerRegistry) httpClient {
+	return httpClient{
+		client: &http.Client{	Transport: otelhttp.NewTransport(&http.Transport{}),
+								Timeout: time.Second * 29 },
+		circuitBreakers: circuitBreakers,
+	}
+}
+
+// About map the status code of a downstream service to the erro values
+func errorStatusCode(statusCode int) error{
+	var err error
+	switch statusCode {
+	case http.StatusUnauthorized:
+		err = erro.ErrUnauthorized
+	case http.StatusForbidden:
+		err = erro.ErrHTTPForbiden
+	case http.StatusBadRequest:
+		err = erro.ErrBadRequest
+	case http.StatusNotFound:
+		err = erro.ErrNotFound
+	case http.StatusServiceUnavailable:
+		err = erro.ErrServiceUnavailable
+	default:
+		err = erro.ErrServer
+	}
+	return err
+}
+
+// About call a downstream service through the circuit breaker of the service name
+// When the circuit breaker is open the call is not made and the error is ErrServiceUnavailable
+// The duration of the call is recorded by service name (downstream.duration)
+func (h httpClient) call(ctx context.Context, 
+						api model.ApiService, 
+						url string, 
+						body interface{}, 
+						result interface{}) (err error) {
+
+	return h.callBreaker(ctx, api.Name, api, url, body, result)
+}
+
+// About call a downstream service through the circuit breaker breakerName (the metrics keep the service name)
+func (h httpClient) callBreaker(ctx context.Context, 
+								breakerName string,
+								api model.ApiService, 
+								url string, 
+								body interface{}, 
+								result interface{}) (err error) {
+
+	start := time.Now()
+	defer func() {
+		metrics.RecordDownstreamCall(ctx, api.Name, start, err)
+	}()
+
+	_, err = h.circuitBreakers.Execute(breakerName, func() (interface{}, error) {
+		return nil, h.do(ctx, api, url, body, result)
+	})
+	if err == gobreaker.ErrOpenState || err == gobreaker.ErrTooManyRequests {
+		childLogger.Error().Err(err).Str("service", api.Name).Str("circuit_breaker", breakerName).Msg("circuit breaker open")
+		return erro.ErrServiceUnavailable
+	}
+
+	return err
+}
+
+// About make the http request and decode the response in the result (the decode errors are not ignored)
+// The idempotency-key of the ctx (when informed) is sent in the header Idempotency-Key
+func (h httpClient) do(ctx context.Context, 
+						api model.ApiService, 
+						url string, 
+						body interface{}, 
+						result interface{}) error {
+	trace_id := fmt.Sprintf("%v",ctx.Value("trace-request-id"))
+
+	payload := new(bytes.Buffer) 
+	if body != nil {
+		err := json.NewEncoder(payload).Encode(body)
+		if err != nil {
+			return errors.New(err.Error())
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, api.Method, url, payload)
+	if err != nil {
+		return errors.New(err.Error())
+	}
+	req.Header.Add("Content-Type", "application/json;charset=UTF-8")
+	req.Header.Add("x-apigw-api-id", api.Header_x_apigw_api_id)
+	req.Header.Add("X-Request-Id", trace_id)
+	if idempotencyKey, ok := ctx.Value("idempotency-key").(string); ok && idempotencyKey != "" {
+		req.Header.Add("Idempotency-Key", idempotencyKey)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		childLogger.Error().Err(err).Str("service", api.Name).Msg("error call service")
+		return erro.ErrServiceUnavailable
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		childLogger.Error().Int("statusCode", resp.StatusCode).Str("service", api.Name).Str("url", url).Msg("error call service")
+		return errorStatusCode(resp.StatusCode)
+	}
+
+	if result == nil {
+		return nil
+	}
+	err = json.NewDecoder(resp.Body).Decode(result)
+	if err != nil {
+		childLogger.Error().Err(err).Str("service", api.Name).Msg("error decode response")
+		return errors.New(err.Error())
+	}
+
+	return nil
+}
+
+// About check a downstream service is reachable (a response below 500 is up)
+// The health check does not go through the circuit breaker, so it does not count as a failure of the service
+func (h httpClient) ping(ctx context.Context, api model.ApiService, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return errors.New(err.Error())
+	}
+	req.Header.Add("x-apigw-api-id", api.Header_x_apigw_api_id)
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return erro.ErrServiceUnavailable
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return errorStatusCode(resp.StatusCode)
+	}
+
+	return nil
+}
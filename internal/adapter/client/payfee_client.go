@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/infra/circuitbreaker"
+)
+
+type PayFeeClient struct {
+	httpClient
+	apiScript	model.ApiService
+	apiFee		model.ApiService
+	healthUrl	string
+}
+
+// About create the http client of go-payfee
+// apiScript (get {url}/{script}), apiFee (get {url}/{fee}) and healthUrl (get, readiness)
+func NewPayFeeClient(	apiScript model.ApiService,
+						apiFee model.ApiService,
+						healthUrl string,
+						circuitBreakers *circuitbreaker.CircuitBreakerRegistry) *PayFeeClient {
+	childLogger.Info().Str("func","NewPayFeeClient").Send()
+
+	return &PayFeeClient{
+		httpClient: newHttpClient(circuitBreakers),
+		apiScript: apiScript,
+		apiFee: apiFee,
+		healthUrl: healthUrl,
+	}
+}
+
+// About get a financial script
+func (p *PayFeeClient) GetScript(ctx context.Context, script string) (*model.Script, error) {
+	childLogger.Info().Str("func","GetScript").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Str("script", script).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "client.GetScript")
+	defer span.End()
+
+	var res_script model.Script
+	err := p.call(ctx, p.apiScript, p.apiScript.Url + "/" + url.PathEscape(script), nil, &res_script)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res_script, nil
+}
+
+// About get a fee definition
+func (p *PayFeeClient) GetFee(ctx context.Context, fee string) (*model.Fee, error) {
+	childLogger.Info().Str("func","GetFee").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Str("fee", fee).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "client.GetFee")
+	defer span.End()
+
+	var res_fee model.Fee
+	err := p.call(ctx, p.apiFee, p.apiFee.Url + "/" + url.PathEscape(fee), nil, &res_fee)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res_fee, nil
+}
+
+// About check go-payfee is reachable (readiness)
+func (p *PayFeeClient) Ping(ctx context.Context) error {
+	return p.ping(ctx, p.apiScript, p.healthUrl)
+}
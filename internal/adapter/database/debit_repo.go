@@ -1,15 +1,18 @@
 package database
 
 import (
+	"fmt"
 	"context"
 	"time"
 	"errors"
 	
 	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/port"
 	go_core_observ "github.com/eliezerraj/go-core/observability"
 	go_core_pg "github.com/eliezerraj/go-core/database/pg"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rs/zerolog/log"
 )
 
@@ -29,8 +32,51 @@ func NewWorkerRepository(databasePGServer *go_core_pg.DatabasePGServer) *WorkerR
 	}
 }
 
+// About the tenant of the request (set by the tenant middleware), every query of the tenant data is scoped by it
+func tenantID(ctx context.Context) string {
+	if ctx.Value("tenant-id") == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v",ctx.Value("tenant-id"))
+}
+
+// About a pg transaction and the connection of the pool that holds it
+type pgTransaction struct {
+	pgx.Tx
+	conn	*pgxpool.Conn
+}
+
+// About the pgx transaction of a port transaction
+func pgTx(tx port.Tx) pgx.Tx {
+	return tx.(*pgTransaction).Tx
+}
+
+// About check the database is reachable with a connection of the pool (fails when the pool is exhausted until the ctx deadline)
+func (w WorkerRepository) Ping(ctx context.Context) error {
+	err := w.DatabasePGServer.GetConnection().Ping(ctx)
+	if err != nil {
+		return errors.New(err.Error())
+	}
+	return nil
+}
+
+// About start a transaction
+func (w WorkerRepository) StartTx(ctx context.Context) (port.Tx, error){
+	tx, conn, err := w.DatabasePGServer.StartTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pgTransaction{Tx: tx, conn: conn}, nil
+}
+
+// About release the connection of the transaction (after the commit or rollback)
+func (w WorkerRepository) ReleaseTx(tx port.Tx) {
+	w.DatabasePGServer.ReleaseTx(tx.(*pgTransaction).conn)
+}
+
 // About add debit
-func (w WorkerRepository) AddDebit(ctx context.Context, tx pgx.Tx, debit *model.AccountStatement) (*model.AccountStatement, error){
+func (w WorkerRepository) AddDebit(ctx context.Context, tx port.Tx, debit *model.AccountStatement) (*model.AccountStatement, error){
 	childLogger.Info().Str("func","AddDebit").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Send()
 
 	// Trace
@@ -47,10 +93,12 @@ func (w WorkerRepository) AddDebit(ctx context.Context, tx pgx.Tx, debit *model.
 											currency,
 											amount,
 											tenant_id,
-											transaction_id) 
-			 VALUES($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+											transaction_id,
+											fee_status,
+											subject) 
+			 VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`
 
-	row := tx.QueryRow(ctx, query, debit.FkAccountID, debit.Type, debit.ChargeAt, debit.Currency, debit.Amount, debit.TenantID, debit.TransactionID)								
+	row := pgTx(tx).QueryRow(ctx, query, debit.FkAccountID, debit.Type, debit.ChargeAt, debit.Currency, debit.Amount, debit.TenantID, debit.TransactionID, debit.FeeStatus, debit.Subject)								
 	var id int
 	if err := row.Scan(&id); err != nil {
 		return nil, errors.New(err.Error())
@@ -61,7 +109,7 @@ func (w WorkerRepository) AddDebit(ctx context.Context, tx pgx.Tx, debit *model.
 	return debit, nil
 }
 
-func (w WorkerRepository) AddAccountStatementFee(ctx context.Context, tx pgx.Tx, accountStatementFee model.AccountStatementFee) (*model.AccountStatementFee, error){
+func (w WorkerRepository) AddAccountStatementFee(ctx context.Context, tx port.Tx, accountStatementFee model.AccountStatementFee) (*model.AccountStatementFee, error){
 	childLogger.Info().Str("func","AddAccountStatementFee").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Send()
 
 	// Trace
@@ -81,7 +129,7 @@ func (w WorkerRepository) AddAccountStatementFee(ctx context.Context, tx pgx.Tx,
 												tenant_id) 
 				VALUES($1, $2, $3, $4, $5, $6, $7) RETURNING id`
 
-	row := tx.QueryRow(ctx, query, accountStatementFee.FkAccountStatementID,
+	row := pgTx(tx).QueryRow(ctx, query, accountStatementFee.FkAccountStatementID,
 									accountStatementFee.ChargeAt,
 									accountStatementFee.TypeFee,
 									accountStatementFee.ValueFee,
@@ -98,7 +146,10 @@ func (w WorkerRepository) AddAccountStatementFee(ctx context.Context, tx pgx.Tx,
 	return &accountStatementFee , nil
 }
 
-func (w WorkerRepository) ListDebit(ctx context.Context, debit *model.AccountStatement) (*[]model.AccountStatement, error){
+// About list the debits of an account (an empty type lists the debits and the reversals)
+// The keyset (charged_at, id) of the pagination is used to start after the last item of the previous page,
+// one extra row is read to detect if there is a next page
+func (w WorkerRepository) ListDebit(ctx context.Context, debit *model.AccountStatement, pagination *model.Pagination) (*[]model.AccountStatement, error){
 	childLogger.Info().Str("func","ListDebit").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Send()
 	
 	// Trace
@@ -123,11 +174,17 @@ func (w WorkerRepository) ListDebit(ctx context.Context, debit *model.AccountSta
 					currency, 
 					amount,																										
 					tenant_id,
-					transaction_id	
+					transaction_id,
+					coalesce(fee_status, ''),
+					coalesce(subject, '')
 					FROM account_statement 
-					WHERE fk_account_id =$1 and type_charge= $2 order by charged_at desc`
+					WHERE fk_account_id =$1 and ($2 = '' or type_charge= $2) 
+					and tenant_id = $6
+					and ($3::timestamptz is null or (charged_at, id) < ($3, $4))
+					order by charged_at desc, id desc
+					limit $5`
 
-	rows, err := conn.Query(ctx, query, debit.FkAccountID, debit.Type)
+	rows, err := conn.Query(ctx, query, debit.FkAccountID, debit.Type, pagination.ChargeAt, pagination.ID, pagination.Limit + 1, tenantID(ctx))
 	if err != nil {
 		return nil, errors.New(err.Error())
 	}
@@ -142,6 +199,8 @@ func (w WorkerRepository) ListDebit(ctx context.Context, debit *model.AccountSta
 							&res_accountStatement.Amount,
 							&res_accountStatement.TenantID,
 							&res_accountStatement.TransactionID,
+							&res_accountStatement.FeeStatus,
+							&res_accountStatement.Subject,
 						)
 		if err != nil {
 			return nil, errors.New(err.Error())
@@ -152,7 +211,9 @@ func (w WorkerRepository) ListDebit(ctx context.Context, debit *model.AccountSta
 	return &res_accountStatement_list , nil
 }
 
-func (w WorkerRepository) ListDebitPerDate(ctx context.Context, debit *model.AccountStatement) (*[]model.AccountStatement, error){
+// About list the debits of an account since a date (and before dateEnd when informed), paginated like ListDebit
+// An empty type lists the debits and the reversals (the export of the statement)
+func (w WorkerRepository) ListDebitPerDate(ctx context.Context, debit *model.AccountStatement, dateEnd *time.Time, pagination *model.Pagination) (*[]model.AccountStatement, error){
 	childLogger.Info().Str("func","ListDebitPerDate").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Send()
 	
 	// Trace
@@ -176,15 +237,21 @@ func (w WorkerRepository) ListDebitPerDate(ctx context.Context, debit *model.Acc
 					charged_at,
 					currency, 
 					amount,																										
-					tenant_id
-					transaction_id	
+					tenant_id,
+					transaction_id,
+					coalesce(fee_status, ''),
+					coalesce(subject, '')
 			FROM account_statement 
 			WHERE fk_account_id =$1 
-			and type_charge= $2
+			and ($2 = '' or type_charge= $2)
 			and charged_at >= $3
-			order by charged_at desc`
+			and ($4::timestamptz is null or (charged_at, id) < ($4, $5))
+			and ($7::timestamptz is null or charged_at < $7)
+			and tenant_id = $8
+			order by charged_at desc, id desc
+			limit $6`
 
-	rows, err := conn.Query(ctx, query, debit.FkAccountID, debit.Type, debit.ChargeAt)
+	rows, err := conn.Query(ctx, query, debit.FkAccountID, debit.Type, debit.ChargeAt, pagination.ChargeAt, pagination.ID, pagination.Limit + 1, dateEnd, tenantID(ctx))
 	if err != nil {
 		return nil, errors.New(err.Error())
 	}
@@ -199,6 +266,8 @@ func (w WorkerRepository) ListDebitPerDate(ctx context.Context, debit *model.Acc
 							&res_accountStatement.Amount,
 							&res_accountStatement.TenantID,
 							&res_accountStatement.TransactionID,
+							&res_accountStatement.FeeStatus,
+							&res_accountStatement.Subject,
 						)
 		if err != nil {
 			return nil, errors.New(err.Error())
@@ -208,39 +277,3 @@ func (w WorkerRepository) ListDebitPerDate(ctx context.Context, debit *model.Acc
 	
 	return &res_accountStatement_list , nil
 }
-
-func (w WorkerRepository) GetTransactionUUID(ctx context.Context) (*string, error){
-	childLogger.Info().Str("func","GetTransactionUUID").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Send()
-	
-	// Trace
-	span := tracerProvider.Span(ctx, "database.GetTransactionUUID")
-	defer span.End()
-
-	conn, err := w.DatabasePGServer.Acquire(ctx)
-	if err != nil {
-		return nil, errors.New(err.Error())
-	}
-	defer w.DatabasePGServer.Release(conn)
-
-	// Prepare
-	var uuid string
-
-	// Query and Execute
-	query := `SELECT uuid_generate_v4()`
-
-	rows, err := conn.Query(ctx, query)
-	if err != nil {
-		return nil, errors.New(err.Error())
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		err := rows.Scan(&uuid) 
-		if err != nil {
-			return nil, errors.New(err.Error())
-        }
-		return &uuid, nil
-	}
-	
-	return &uuid, nil
-}
@@ -0,0 +1,162 @@
+package database
+
+import (
+	"context"
+	"time"
+	"errors"
+
+	"github.com/go-debit/internal/core/model"
+
+	"github.com/shopspring/decimal"
+)
+
+// About list the fees of a set of statements (one query per page of the export)
+func (w WorkerRepository) ListAccountStatementFeePerStatement(ctx context.Context, accountStatementIDs []int) (*[]model.AccountStatementFee, error){
+	childLogger.Info().Str("func","ListAccountStatementFeePerStatement").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "database.ListAccountStatementFeePerStatement")
+	defer span.End()
+
+	conn, err := w.DatabasePGServer.Acquire(ctx)
+	if err != nil {
+		return nil, errors.New(err.Error())
+	}
+	defer w.DatabasePGServer.Release(conn)
+
+	// Prepare
+	res_accountStatementFee := model.AccountStatementFee{}
+	res_accountStatementFee_list := []model.AccountStatementFee{}
+
+	// Query e Execute
+	query := `SELECT id, 
+					fk_account_statement_id,
+					charged_at,
+					type_fee,
+					value_fee,
+					currency,
+					amount,
+					tenant_id
+				FROM account_statement_fee 
+				WHERE fk_account_statement_id = any($1)
+				and tenant_id = $2
+				order by fk_account_statement_id, id`
+
+	rows, err := conn.Query(ctx, query, accountStatementIDs, tenantID(ctx))
+	if err != nil {
+		return nil, errors.New(err.Error())
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan( 	&res_accountStatementFee.ID, 
+							&res_accountStatementFee.FkAccountStatementID, 
+							&res_accountStatementFee.ChargeAt,
+							&res_accountStatementFee.TypeFee, 
+							&res_accountStatementFee.ValueFee,
+							&res_accountStatementFee.Currency,
+							&res_accountStatementFee.Amount,
+							&res_accountStatementFee.TenantID,
+						)
+		if err != nil {
+			return nil, errors.New(err.Error())
+        }
+		res_accountStatementFee_list = append(res_accountStatementFee_list, res_accountStatementFee)
+	}
+	
+	return &res_accountStatementFee_list , nil
+}
+
+// About list all the statements (debits and reversals) of the account in a period, oldest first
+func (w WorkerRepository) ListStatementPerPeriod(ctx context.Context, fkAccountID int, dateStart time.Time, dateEnd time.Time) (*[]model.AccountStatement, error){
+	childLogger.Info().Str("func","ListStatementPerPeriod").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "database.ListStatementPerPeriod")
+	defer span.End()
+
+	conn, err := w.DatabasePGServer.Acquire(ctx)
+	if err != nil {
+		return nil, errors.New(err.Error())
+	}
+	defer w.DatabasePGServer.Release(conn)
+
+	// Prepare
+	res_accountStatement := model.AccountStatement{}
+	res_accountStatement_list := []model.AccountStatement{}
+
+	// Query e Execute
+	query := `SELECT id, 
+					fk_account_id, 
+					type_charge,
+					charged_at,
+					currency, 
+					amount,																										
+					tenant_id,
+					transaction_id,
+					reversed_statement_id
+				FROM account_statement 
+				WHERE fk_account_id = $1
+				and charged_at >= $2
+				and charged_at < $3
+				and tenant_id = $4
+				order by charged_at, id`
+
+	rows, err := conn.Query(ctx, query, fkAccountID, dateStart, dateEnd, tenantID(ctx))
+	if err != nil {
+		return nil, errors.New(err.Error())
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan( 	&res_accountStatement.ID, 
+							&res_accountStatement.FkAccountID, 
+							&res_accountStatement.Type, 
+							&res_accountStatement.ChargeAt,
+							&res_accountStatement.Currency,
+							&res_accountStatement.Amount,
+							&res_accountStatement.TenantID,
+							&res_accountStatement.TransactionID,
+							&res_accountStatement.ReversedStatementID,
+						)
+		if err != nil {
+			return nil, errors.New(err.Error())
+        }
+		res_accountStatement_list = append(res_accountStatement_list, res_accountStatement)
+	}
+	
+	return &res_accountStatement_list , nil
+}
+
+// About sum the statements of the account since a date already posted to the account balance (the pending or failed ones in the outbox are not in the balance yet)
+func (w WorkerRepository) GetPostedAmountSince(ctx context.Context, fkAccountID int, dateStart time.Time) (decimal.Decimal, error){
+	childLogger.Info().Str("func","GetPostedAmountSince").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "database.GetPostedAmountSince")
+	defer span.End()
+
+	conn, err := w.DatabasePGServer.Acquire(ctx)
+	if err != nil {
+		return decimal.Zero, errors.New(err.Error())
+	}
+	defer w.DatabasePGServer.Release(conn)
+
+	// Query e Execute
+	query := `SELECT coalesce(sum(s.amount), 0)
+				FROM account_statement s
+				WHERE s.fk_account_id = $1
+				and s.charged_at >= $2
+				and s.tenant_id = $3
+				and not exists (SELECT 1 FROM debit_outbox o 
+								WHERE o.fk_account_statement_id = s.id 
+								and o.event_type = 'ACCOUNT_BALANCE_POSTING'
+								and o.status <> 'DELIVERED')`
+
+	var amount decimal.Decimal
+	if err := conn.QueryRow(ctx, query, fkAccountID, dateStart, tenantID(ctx)).Scan(&amount); err != nil {
+		return decimal.Zero, errors.New(err.Error())
+	}
+
+	return amount, nil
+}
@@ -0,0 +1,84 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/port"
+	"github.com/go-debit/internal/core/erro"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// About lock the account until the end of the transaction, so the debits of the same account are checked one at a time
+func (w WorkerRepository) LockAccount(ctx context.Context, tx port.Tx, fkAccountID int) error{
+	childLogger.Info().Str("func","LockAccount").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Int("fk_account_id", fkAccountID).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "database.LockAccount")
+	defer span.End()
+
+	query := `SELECT pg_advisory_xact_lock($1)`
+
+	_, err := pgTx(tx).Exec(ctx, query, fkAccountID)
+	if err != nil {
+		return errors.New(err.Error())
+	}
+
+	return nil
+}
+
+// About sum the statements of the account not yet posted to the account balance (pending in the outbox)
+func (w WorkerRepository) GetPendingPostingAmount(ctx context.Context, tx port.Tx, fkAccountID int) (decimal.Decimal, error){
+	childLogger.Info().Str("func","GetPendingPostingAmount").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Int("fk_account_id", fkAccountID).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "database.GetPendingPostingAmount")
+	defer span.End()
+
+	// Query e Execute
+	query := `SELECT coalesce(sum(s.amount), 0)
+				FROM account_statement s
+				JOIN debit_outbox o on o.fk_account_statement_id = s.id
+				WHERE s.fk_account_id = $1
+				and s.tenant_id = $2
+				and o.event_type = 'ACCOUNT_BALANCE_POSTING'
+				and o.status <> 'DELIVERED'`
+
+	var amount decimal.Decimal
+	if err := pgTx(tx).QueryRow(ctx, query, fkAccountID, tenantID(ctx)).Scan(&amount); err != nil {
+		return decimal.Zero, errors.New(err.Error())
+	}
+
+	return amount, nil
+}
+
+// About get the overdraft limit of an account (overrides the limit of the tenant)
+func (w WorkerRepository) GetOverdraftLimit(ctx context.Context, tx port.Tx, fkAccountID int) (*model.OverdraftPolicy, error){
+	childLogger.Info().Str("func","GetOverdraftLimit").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Int("fk_account_id", fkAccountID).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "database.GetOverdraftLimit")
+	defer span.End()
+
+	// Prepare
+	res_overdraftPolicy := model.OverdraftPolicy{CheckFunds: true}
+
+	// Query e Execute
+	query := `SELECT overdraft_limit
+				FROM debit_overdraft_limit
+				WHERE fk_account_id = $1
+				and tenant_id = $2`
+
+	err := pgTx(tx).QueryRow(ctx, query, fkAccountID, tenantID(ctx)).Scan(&res_overdraftPolicy.Limit)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, erro.ErrNotFound
+		}
+		return nil, errors.New(err.Error())
+	}
+
+	return &res_overdraftPolicy, nil
+}
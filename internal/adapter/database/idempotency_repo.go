@@ -0,0 +1,116 @@
+package database
+
+import (
+	"context"
+	"time"
+	"errors"
+
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/port"
+	"github.com/go-debit/internal/core/erro"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// About reserve an idempotency key
+// The insert blocks while another transaction holds the same key, so a concurrent retry
+// only proceeds after the first request was committed (or rolled back)
+func (w WorkerRepository) AddIdempotencyKey(ctx context.Context, tx port.Tx, idempotencyKey *model.IdempotencyKey) (*model.IdempotencyKey, error){
+	childLogger.Info().Str("func","AddIdempotencyKey").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "database.AddIdempotencyKey")
+	defer span.End()
+
+	//Prepare
+	idempotencyKey.CreateAt = time.Now()
+
+	// Execute e Query
+	query := `INSERT INTO debit_idempotency (idempotency_key, 
+											request_hash,
+											tenant_id,
+											created_at) 
+			 VALUES($1, $2, $3, $4) 
+			 ON CONFLICT (tenant_id, idempotency_key) DO NOTHING 
+			 RETURNING idempotency_key`
+
+	row := pgTx(tx).QueryRow(ctx, query, idempotencyKey.Key, idempotencyKey.RequestHash, idempotencyKey.TenantID, idempotencyKey.CreateAt)
+	var key string
+	if err := row.Scan(&key); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, erro.ErrDuplicateKey
+		}
+		return nil, errors.New(err.Error())
+	}
+
+	return idempotencyKey, nil
+}
+
+// About get an idempotency key already stored
+func (w WorkerRepository) GetIdempotencyKey(ctx context.Context, tx port.Tx, idempotencyKey *model.IdempotencyKey) (*model.IdempotencyKey, error){
+	childLogger.Info().Str("func","GetIdempotencyKey").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "database.GetIdempotencyKey")
+	defer span.End()
+
+	// Prepare
+	res_idempotencyKey := model.IdempotencyKey{}
+
+	// Query e Execute
+	query := `SELECT idempotency_key, 
+					request_hash,
+					coalesce(status_code, 0),
+					response,
+					coalesce(tenant_id, ''),
+					created_at,
+					updated_at
+				FROM debit_idempotency 
+				WHERE idempotency_key =$1
+				and tenant_id = $2`
+
+	row := pgTx(tx).QueryRow(ctx, query, idempotencyKey.Key, tenantID(ctx))
+	err := row.Scan(&res_idempotencyKey.Key,
+					&res_idempotencyKey.RequestHash,
+					&res_idempotencyKey.StatusCode,
+					&res_idempotencyKey.Response,
+					&res_idempotencyKey.TenantID,
+					&res_idempotencyKey.CreateAt,
+					&res_idempotencyKey.UpdateAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, erro.ErrNotFound
+		}
+		return nil, errors.New(err.Error())
+	}
+
+	return &res_idempotencyKey, nil
+}
+
+// About store the response replayed for an idempotency key
+func (w WorkerRepository) UpdateIdempotencyKey(ctx context.Context, tx port.Tx, idempotencyKey *model.IdempotencyKey) (int64, error){
+	childLogger.Info().Str("func","UpdateIdempotencyKey").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "database.UpdateIdempotencyKey")
+	defer span.End()
+
+	//Prepare
+	update_at := time.Now()
+	idempotencyKey.UpdateAt = &update_at
+
+	// Execute e Query
+	query := `UPDATE debit_idempotency
+				SET status_code = $2,
+					response = $3,
+					updated_at = $4
+				WHERE idempotency_key = $1
+				and tenant_id = $5`
+
+	row, err := pgTx(tx).Exec(ctx, query, idempotencyKey.Key, idempotencyKey.StatusCode, idempotencyKey.Response, idempotencyKey.UpdateAt, tenantID(ctx))
+	if err != nil {
+		return 0, errors.New(err.Error())
+	}
+
+	return row.RowsAffected(), nil
+}
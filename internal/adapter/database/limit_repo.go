@@ -0,0 +1,40 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/port"
+)
+
+// About sum the debits of the account in the day and in the month of the limit periods
+func (w WorkerRepository) GetDebitUsage(ctx context.Context, tx port.Tx, fkAccountID int, debitLimit *model.DebitLimit) (*model.DebitLimit, error){
+	childLogger.Info().Str("func","GetDebitUsage").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Int("fk_account_id", fkAccountID).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "database.GetDebitUsage")
+	defer span.End()
+
+	// Query e Execute (the amounts of the debits are negative)
+	query := `SELECT coalesce(-sum(amount) filter (where charged_at >= $3), 0),
+					count(*) filter (where charged_at >= $3),
+					coalesce(-sum(amount), 0),
+					count(*)
+				FROM account_statement 
+				WHERE fk_account_id = $1
+				and type_charge = 'DEBIT'
+				and charged_at >= $2
+				and tenant_id = $4`
+
+	row := pgTx(tx).QueryRow(ctx, query, fkAccountID, debitLimit.Monthly.PeriodStart, debitLimit.Daily.PeriodStart, tenantID(ctx))
+	err := row.Scan(&debitLimit.Daily.UsedAmount,
+					&debitLimit.Daily.UsedCount,
+					&debitLimit.Monthly.UsedAmount,
+					&debitLimit.Monthly.UsedCount)
+	if err != nil {
+		return nil, errors.New(err.Error())
+	}
+
+	return debitLimit, nil
+}
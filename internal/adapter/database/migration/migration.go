@@ -0,0 +1,337 @@
+package migration
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"errors"
+
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/erro"
+	go_core_pg "github.com/eliezerraj/go-core/database/pg"
+
+	"github.com/rs/zerolog/log"
+)
+
+var childLogger = log.With().Str("component","go-debit").Str("package","internal.adapter.database.migration").Logger()
+
+// The migrations are embedded in the binary, named {version}_{name}.up.sql and {version}_{name}.down.sql
+//go:embed sql/*.sql
+var migrationFS embed.FS
+
+// Serializes the migrations of the pods started at the same time
+const migrationLockID = 7402019
+
+type migration struct {
+	version	int
+	name	string
+	up		string
+	down	string
+}
+
+type Migrator struct {
+	DatabasePGServer	*go_core_pg.DatabasePGServer
+	migrations			[]migration
+}
+
+func NewMigrator(databasePGServer *go_core_pg.DatabasePGServer) (*Migrator, error){
+	childLogger.Info().Str("func","NewMigrator").Send()
+
+	migrations, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		DatabasePGServer: databasePGServer,
+		migrations: migrations,
+	}, nil
+}
+
+// About load the embedded migrations (every version needs the up and the down)
+func loadMigrations() ([]migration, error){
+	entries, err := migrationFS.ReadDir("sql")
+	if err != nil {
+		return nil, errors.New(err.Error())
+	}
+
+	list_migration := map[int]*migration{}
+	for _, entry := range entries {
+		file_name := entry.Name()
+
+		var direction string
+		switch {
+		case strings.HasSuffix(file_name, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(file_name, ".down.sql"):
+			direction = "down"
+		default:
+			return nil, fmt.Errorf("%w: %s", erro.ErrInvalidMigration, file_name)
+		}
+
+		version_name := strings.SplitN(strings.TrimSuffix(file_name, "." + direction + ".sql"), "_", 2)
+		if len(version_name) != 2 {
+			return nil, fmt.Errorf("%w: %s", erro.ErrInvalidMigration, file_name)
+		}
+		version, err := strconv.Atoi(version_name[0])
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("%w: %s", erro.ErrInvalidMigration, file_name)
+		}
+
+		content, err := migrationFS.ReadFile("sql/" + file_name)
+		if err != nil {
+			return nil, errors.New(err.Error())
+		}
+
+		m, ok := list_migration[version]
+		if !ok {
+			m = &migration{version: version, name: version_name[1]}
+			list_migration[version] = m
+		}
+		if m.name != version_name[1] {
+			return nil, fmt.Errorf("%w: version %d with two names", erro.ErrInvalidMigration, version)
+		}
+		if direction == "up" {
+			m.up = string(content)
+		} else {
+			m.down = string(content)
+		}
+	}
+
+	res_migrations := []migration{}
+	for _, m := range list_migration {
+		if m.up == "" || m.down == "" {
+			return nil, fmt.Errorf("%w: version %d without up or down", erro.ErrInvalidMigration, m.version)
+		}
+		res_migrations = append(res_migrations, *m)
+	}
+	sort.Slice(res_migrations, func(i, j int) bool { return res_migrations[i].version < res_migrations[j].version })
+
+	return res_migrations, nil
+}
+
+// About the version of the last embedded migration (the version the binary needs)
+func (m *Migrator) LatestVersion() int {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].version
+}
+
+// About create the tracking table of the applied migrations
+func (m *Migrator) createMigrationTable(ctx context.Context) error{
+	conn, err := m.DatabasePGServer.Acquire(ctx)
+	if err != nil {
+		return errors.New(err.Error())
+	}
+	defer m.DatabasePGServer.Release(conn)
+
+	query := `CREATE TABLE IF NOT EXISTS public.schema_migrations (
+					version		int4 NOT NULL,
+					name		varchar(255) NOT NULL,
+					applied_at	timestamptz NOT NULL DEFAULT now(),
+					CONSTRAINT schema_migrations_pkey PRIMARY KEY (version))`
+
+	_, err = conn.Exec(ctx, query)
+	if err != nil {
+		return errors.New(err.Error())
+	}
+
+	return nil
+}
+
+// About the applied migrations (version and applied_at), empty when the tracking table does not exist
+func (m *Migrator) appliedMigrations(ctx context.Context) (map[int]time.Time, error){
+	conn, err := m.DatabasePGServer.Acquire(ctx)
+	if err != nil {
+		return nil, errors.New(err.Error())
+	}
+	defer m.DatabasePGServer.Release(conn)
+
+	res_applied := map[int]time.Time{}
+
+	var exists bool
+	err = conn.QueryRow(ctx, `SELECT to_regclass('public.schema_migrations') is not null`).Scan(&exists)
+	if err != nil {
+		return nil, errors.New(err.Error())
+	}
+	if !exists {
+		return res_applied, nil
+	}
+
+	rows, err := conn.Query(ctx, `SELECT version, applied_at FROM public.schema_migrations`)
+	if err != nil {
+		return nil, errors.New(err.Error())
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var version int
+		var applied_at time.Time
+		err := rows.Scan(&version, &applied_at)
+		if err != nil {
+			return nil, errors.New(err.Error())
+		}
+		res_applied[version] = applied_at
+	}
+
+	return res_applied, nil
+}
+
+// About run a migration and record it, in one transaction holding the migration lock
+// The applied versions are read again after the lock, another pod may have migrated in the meantime
+func (m *Migrator) apply(ctx context.Context, mig migration, direction string) (applied bool, err error){
+	tx, conn, err := m.DatabasePGServer.StartTx(ctx)
+	if err != nil {
+		return false, errors.New(err.Error())
+	}
+	defer m.DatabasePGServer.ReleaseTx(conn)
+
+	// Handle the transaction
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	_, err = tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, migrationLockID)
+	if err != nil {
+		return false, errors.New(err.Error())
+	}
+
+	var exists bool
+	err = tx.QueryRow(ctx, `SELECT exists(SELECT 1 FROM public.schema_migrations WHERE version = $1)`, mig.version).Scan(&exists)
+	if err != nil {
+		return false, errors.New(err.Error())
+	}
+
+	if direction == "up" {
+		if exists {
+			return false, nil
+		}
+		_, err = tx.Exec(ctx, mig.up)
+		if err != nil {
+			return false, fmt.Errorf("migration %d_%s up: %s", mig.version, mig.name, err.Error())
+		}
+		_, err = tx.Exec(ctx, `INSERT INTO public.schema_migrations (version, name, applied_at) VALUES($1, $2, $3)`, mig.version, mig.name, time.Now())
+		if err != nil {
+			return false, errors.New(err.Error())
+		}
+	} else {
+		if !exists {
+			return false, nil
+		}
+		_, err = tx.Exec(ctx, mig.down)
+		if err != nil {
+			return false, fmt.Errorf("migration %d_%s down: %s", mig.version, mig.name, err.Error())
+		}
+		_, err = tx.Exec(ctx, `DELETE FROM public.schema_migrations WHERE version = $1`, mig.version)
+		if err != nil {
+			return false, errors.New(err.Error())
+		}
+	}
+
+	return true, nil
+}
+
+// About apply the pending migrations in the order of the version, returns how many were applied
+func (m *Migrator) Up(ctx context.Context) (int, error){
+	childLogger.Info().Str("func","Up").Send()
+
+	err := m.createMigrationTable(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, mig := range m.migrations {
+		applied, err := m.apply(ctx, mig, "up")
+		if err != nil {
+			return count, err
+		}
+		if applied {
+			childLogger.Info().Int("version", mig.version).Str("name", mig.name).Msg("migration applied")
+			count = count + 1
+		}
+	}
+
+	return count, nil
+}
+
+// About revert the last applied migrations (steps), returns how many were reverted
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error){
+	childLogger.Info().Str("func","Down").Int("steps", steps).Send()
+
+	if steps <= 0 {
+		return 0, erro.ErrInvalidMigration
+	}
+
+	list_applied, err := m.appliedMigrations(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for i := len(m.migrations)-1; i >= 0 && count < steps; i-- {
+		mig := m.migrations[i]
+		if _, ok := list_applied[mig.version]; !ok {
+			continue
+		}
+		reverted, err := m.apply(ctx, mig, "down")
+		if err != nil {
+			return count, err
+		}
+		if reverted {
+			childLogger.Info().Int("version", mig.version).Str("name", mig.name).Msg("migration reverted")
+			count = count + 1
+		}
+	}
+
+	return count, nil
+}
+
+// About the embedded migrations and when they were applied
+func (m *Migrator) Status(ctx context.Context) (*[]model.SchemaMigration, error){
+	childLogger.Info().Str("func","Status").Send()
+
+	list_applied, err := m.appliedMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res_schemaMigration := []model.SchemaMigration{}
+	for _, mig := range m.migrations {
+		schemaMigration := model.SchemaMigration{Version: mig.version, Name: mig.name}
+		if applied_at, ok := list_applied[mig.version]; ok {
+			schemaMigration.AppliedAt = &applied_at
+		}
+		res_schemaMigration = append(res_schemaMigration, schemaMigration)
+	}
+
+	return &res_schemaMigration, nil
+}
+
+// About check the schema has all the migrations of the binary (a newer schema is accepted, the migrations are additive)
+func (m *Migrator) CheckVersion(ctx context.Context) error{
+	childLogger.Info().Str("func","CheckVersion").Send()
+
+	list_applied, err := m.appliedMigrations(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, mig := range m.migrations {
+		if _, ok := list_applied[mig.version]; !ok {
+			childLogger.Error().Int("version", mig.version).Str("name", mig.name).Int("latest_version", m.LatestVersion()).Msg("migration not applied")
+			return erro.ErrSchemaVersion
+		}
+	}
+
+	return nil
+}
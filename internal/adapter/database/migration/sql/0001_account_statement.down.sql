@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS public.account_statement_fee;
+DROP TABLE IF EXISTS public.account_statement;
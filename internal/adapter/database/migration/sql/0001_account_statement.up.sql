@@ -0,0 +1,24 @@
+-- Tables of the statements and fees of the debits (IF NOT EXISTS adopts the schema created by go-account-migration-worker)
+CREATE TABLE IF NOT EXISTS public.account_statement (
+    id              serial4 NOT NULL,
+    fk_account_id   int4 NOT NULL,
+    type_charge     varchar(200) NOT NULL,
+    charged_at      timestamptz NOT NULL,
+    currency        varchar(10) NOT NULL,
+    amount          float8 NOT NULL,
+    tenant_id       varchar(200) NULL,
+    transaction_id  varchar(200) NULL,
+    CONSTRAINT account_statement_pkey PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS public.account_statement_fee (
+    id                      serial4 NOT NULL,
+    fk_account_statement_id int4 NOT NULL REFERENCES public.account_statement(id),
+    charged_at              timestamptz NOT NULL,
+    type_fee                varchar(200) NOT NULL,
+    value_fee               float8 NOT NULL,
+    currency                varchar(10) NOT NULL,
+    amount                  float8 NOT NULL,
+    tenant_id               varchar(200) NULL,
+    CONSTRAINT account_statement_fee_pkey PRIMARY KEY (id)
+);
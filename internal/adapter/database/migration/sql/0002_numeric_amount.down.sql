@@ -0,0 +1,3 @@
+ALTER TABLE public.account_statement_fee ALTER COLUMN value_fee TYPE float8;
+ALTER TABLE public.account_statement_fee ALTER COLUMN amount TYPE float8;
+ALTER TABLE public.account_statement ALTER COLUMN amount TYPE float8;
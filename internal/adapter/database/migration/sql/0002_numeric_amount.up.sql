@@ -0,0 +1,4 @@
+-- The amounts are exact decimals, rounded to the minor unit of the currency by the service
+ALTER TABLE public.account_statement ALTER COLUMN amount TYPE numeric(20,6);
+ALTER TABLE public.account_statement_fee ALTER COLUMN amount TYPE numeric(20,6);
+ALTER TABLE public.account_statement_fee ALTER COLUMN value_fee TYPE numeric(20,6);
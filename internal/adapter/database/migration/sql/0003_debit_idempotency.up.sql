@@ -0,0 +1,11 @@
+-- Idempotency keys used by POST /add
+CREATE TABLE IF NOT EXISTS public.debit_idempotency (
+    idempotency_key varchar(255) NOT NULL,
+    request_hash    varchar(64) NOT NULL,
+    status_code     int4 NULL,
+    response        jsonb NULL,
+    tenant_id       varchar(100) NOT NULL,
+    created_at      timestamptz NOT NULL,
+    updated_at      timestamptz NULL,
+    CONSTRAINT debit_idempotency_pkey PRIMARY KEY (tenant_id, idempotency_key)
+);
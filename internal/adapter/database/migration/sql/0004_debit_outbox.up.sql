@@ -0,0 +1,17 @@
+-- Outbox of the balance postings to go-account and of the domain events
+CREATE TABLE IF NOT EXISTS public.debit_outbox (
+    id                      serial4 NOT NULL,
+    fk_account_statement_id int4 NOT NULL REFERENCES public.account_statement(id),
+    transaction_id          varchar(100) NULL,
+    event_type              varchar(100) NOT NULL,
+    payload                 jsonb NOT NULL,
+    status                  varchar(20) NOT NULL,
+    attempts                int4 NOT NULL DEFAULT 0,
+    next_attempt_at         timestamptz NOT NULL,
+    last_error              varchar(255) NULL,
+    trace_id                varchar(100) NULL,
+    created_at              timestamptz NOT NULL,
+    delivered_at            timestamptz NULL,
+    CONSTRAINT debit_outbox_pkey PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS debit_outbox_pending_idx ON public.debit_outbox (next_attempt_at) WHERE status = 'PENDING';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS public.debit_pending_fee;
+ALTER TABLE public.account_statement DROP COLUMN IF EXISTS fee_status;
@@ -0,0 +1,19 @@
+-- Fees queued while go-payfee is unavailable
+ALTER TABLE public.account_statement ADD COLUMN IF NOT EXISTS fee_status varchar(20) NULL;
+
+CREATE TABLE IF NOT EXISTS public.debit_pending_fee (
+    id                      serial4 NOT NULL,
+    fk_account_statement_id int4 NOT NULL REFERENCES public.account_statement(id),
+    currency                varchar(10) NOT NULL,
+    amount                  numeric(20,6) NOT NULL,
+    tenant_id               varchar(100) NULL,
+    status                  varchar(20) NOT NULL,
+    attempts                int4 NOT NULL DEFAULT 0,
+    next_attempt_at         timestamptz NOT NULL,
+    last_error              varchar(255) NULL,
+    trace_id                varchar(100) NULL,
+    created_at              timestamptz NOT NULL,
+    updated_at              timestamptz NULL,
+    CONSTRAINT debit_pending_fee_pkey PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS debit_pending_fee_pending_idx ON public.debit_pending_fee (next_attempt_at) WHERE status = 'PENDING';
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS public.debit_overdraft_limit;
@@ -0,0 +1,8 @@
+-- Overdraft limit per account (overrides the limit of the tenant)
+CREATE TABLE IF NOT EXISTS public.debit_overdraft_limit (
+    fk_account_id   int4 NOT NULL,
+    overdraft_limit numeric(20,6) NOT NULL,
+    tenant_id       varchar(100) NULL,
+    created_at      timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT debit_overdraft_limit_pkey PRIMARY KEY (fk_account_id)
+);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS public.account_statement_keyset_idx;
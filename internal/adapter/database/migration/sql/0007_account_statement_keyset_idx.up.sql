@@ -0,0 +1,2 @@
+-- Index used by the keyset pagination
+CREATE INDEX IF NOT EXISTS account_statement_keyset_idx ON public.account_statement (fk_account_id, type_charge, charged_at DESC, id DESC);
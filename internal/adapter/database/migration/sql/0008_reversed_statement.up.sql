@@ -0,0 +1,2 @@
+-- Reversals are linked to the original debit
+ALTER TABLE public.account_statement ADD COLUMN IF NOT EXISTS reversed_statement_id int4 NULL REFERENCES public.account_statement(id);
@@ -0,0 +1 @@
+ALTER TABLE public.account_statement DROP COLUMN IF EXISTS subject;
@@ -0,0 +1,2 @@
+-- Subject of the JWT that created the statement (audit)
+ALTER TABLE public.account_statement ADD COLUMN IF NOT EXISTS subject varchar(255) NULL;
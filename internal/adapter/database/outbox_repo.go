@@ -0,0 +1,144 @@
+package database
+
+import (
+	"context"
+	"time"
+	"errors"
+
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/port"
+)
+
+// About add a message in the outbox (same transaction of the account statement)
+func (w WorkerRepository) AddOutbox(ctx context.Context, tx port.Tx, outbox *model.Outbox) (*model.Outbox, error){
+	childLogger.Info().Str("func","AddOutbox").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "database.AddOutbox")
+	defer span.End()
+
+	//Prepare
+	outbox.CreateAt = time.Now()
+	outbox.NextAttemptAt = outbox.CreateAt
+	outbox.Status = "PENDING"
+
+	// Execute e Query
+	query := `INSERT INTO debit_outbox (fk_account_statement_id, 
+										transaction_id,
+										event_type,
+										payload,
+										status,
+										attempts,
+										next_attempt_at,
+										trace_id,
+										created_at) 
+			 VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`
+
+	row := pgTx(tx).QueryRow(ctx, query,	outbox.FkAccountStatementID,
+									outbox.TransactionID,
+									outbox.EventType,
+									outbox.Payload,
+									outbox.Status,
+									outbox.Attempts,
+									outbox.NextAttemptAt,
+									outbox.TraceID,
+									outbox.CreateAt)
+	var id int
+	if err := row.Scan(&id); err != nil {
+		return nil, errors.New(err.Error())
+	}
+
+	outbox.ID = id
+
+	return outbox, nil
+}
+
+// About list the pending messages ready to be delivered
+// The rows are locked (skip locked) so each message is dispatched by only one pod
+func (w WorkerRepository) ListPendingOutbox(ctx context.Context, tx port.Tx, limit int) (*[]model.Outbox, error){
+	childLogger.Debug().Str("func","ListPendingOutbox").Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "database.ListPendingOutbox")
+	defer span.End()
+
+	// Prepare
+	res_outbox := model.Outbox{}
+	res_outbox_list := []model.Outbox{}
+
+	// Query e Execute
+	query := `SELECT id, 
+					fk_account_statement_id,
+					transaction_id,
+					event_type,
+					payload,
+					status,
+					attempts,
+					next_attempt_at,
+					last_error,
+					coalesce(trace_id, ''),
+					created_at
+				FROM debit_outbox 
+				WHERE status = 'PENDING'
+				and next_attempt_at <= $1
+				order by id
+				limit $2
+				FOR UPDATE SKIP LOCKED`
+
+	rows, err := pgTx(tx).Query(ctx, query, time.Now(), limit)
+	if err != nil {
+		return nil, errors.New(err.Error())
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan( 	&res_outbox.ID, 
+							&res_outbox.FkAccountStatementID, 
+							&res_outbox.TransactionID, 
+							&res_outbox.EventType,
+							&res_outbox.Payload,
+							&res_outbox.Status,
+							&res_outbox.Attempts,
+							&res_outbox.NextAttemptAt,
+							&res_outbox.LastError,
+							&res_outbox.TraceID,
+							&res_outbox.CreateAt,
+						)
+		if err != nil {
+			return nil, errors.New(err.Error())
+        }
+		res_outbox_list = append(res_outbox_list, res_outbox)
+	}
+	
+	return &res_outbox_list , nil
+}
+
+// About update the delivery status of a message
+func (w WorkerRepository) UpdateOutbox(ctx context.Context, tx port.Tx, outbox *model.Outbox) (int64, error){
+	childLogger.Debug().Str("func","UpdateOutbox").Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "database.UpdateOutbox")
+	defer span.End()
+
+	// Execute e Query
+	query := `UPDATE debit_outbox
+				SET status = $2,
+					attempts = $3,
+					next_attempt_at = $4,
+					last_error = $5,
+					delivered_at = $6
+				WHERE id = $1`
+
+	row, err := pgTx(tx).Exec(ctx, query,	outbox.ID,
+									outbox.Status,
+									outbox.Attempts,
+									outbox.NextAttemptAt,
+									outbox.LastError,
+									outbox.DeliveredAt)
+	if err != nil {
+		return 0, errors.New(err.Error())
+	}
+
+	return row.RowsAffected(), nil
+}
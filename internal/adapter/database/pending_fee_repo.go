@@ -0,0 +1,243 @@
+package database
+
+import (
+	"context"
+	"time"
+	"errors"
+
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/port"
+	"github.com/go-debit/internal/core/erro"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// About add a pending fee (the fees of the statement could not be calculated)
+func (w WorkerRepository) AddPendingFee(ctx context.Context, tx port.Tx, pendingFee *model.PendingFee) (*model.PendingFee, error){
+	childLogger.Info().Str("func","AddPendingFee").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "database.AddPendingFee")
+	defer span.End()
+
+	//Prepare
+	pendingFee.CreateAt = time.Now()
+	pendingFee.NextAttemptAt = pendingFee.CreateAt
+	pendingFee.Status = "PENDING"
+
+	// Execute e Query
+	query := `INSERT INTO debit_pending_fee (fk_account_statement_id, 
+											currency,
+											amount,
+											tenant_id,
+											status,
+											attempts,
+											next_attempt_at,
+											trace_id,
+											created_at) 
+			 VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`
+
+	row := pgTx(tx).QueryRow(ctx, query,	pendingFee.FkAccountStatementID,
+									pendingFee.Currency,
+									pendingFee.Amount,
+									pendingFee.TenantID,
+									pendingFee.Status,
+									pendingFee.Attempts,
+									pendingFee.NextAttemptAt,
+									pendingFee.TraceID,
+									pendingFee.CreateAt)
+	var id int
+	if err := row.Scan(&id); err != nil {
+		return nil, errors.New(err.Error())
+	}
+
+	pendingFee.ID = id
+
+	return pendingFee, nil
+}
+
+// About list the pending fees ready to be charged
+// The rows are locked (skip locked) so each pending fee is charged by only one pod
+func (w WorkerRepository) ListPendingFee(ctx context.Context, tx port.Tx, limit int) (*[]model.PendingFee, error){
+	childLogger.Debug().Str("func","ListPendingFee").Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "database.ListPendingFee")
+	defer span.End()
+
+	// Prepare
+	res_pendingFee := model.PendingFee{}
+	res_pendingFee_list := []model.PendingFee{}
+
+	// Query e Execute
+	query := `SELECT id, 
+					fk_account_statement_id,
+					currency,
+					amount,
+					tenant_id,
+					status,
+					attempts,
+					next_attempt_at,
+					last_error,
+					coalesce(trace_id, ''),
+					created_at
+				FROM debit_pending_fee 
+				WHERE status = 'PENDING'
+				and next_attempt_at <= $1
+				order by id
+				limit $2
+				FOR UPDATE SKIP LOCKED`
+
+	rows, err := pgTx(tx).Query(ctx, query, time.Now(), limit)
+	if err != nil {
+		return nil, errors.New(err.Error())
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan( 	&res_pendingFee.ID, 
+							&res_pendingFee.FkAccountStatementID, 
+							&res_pendingFee.Currency, 
+							&res_pendingFee.Amount,
+							&res_pendingFee.TenantID,
+							&res_pendingFee.Status,
+							&res_pendingFee.Attempts,
+							&res_pendingFee.NextAttemptAt,
+							&res_pendingFee.LastError,
+							&res_pendingFee.TraceID,
+							&res_pendingFee.CreateAt,
+						)
+		if err != nil {
+			return nil, errors.New(err.Error())
+        }
+		res_pendingFee_list = append(res_pendingFee_list, res_pendingFee)
+	}
+	
+	return &res_pendingFee_list , nil
+}
+
+// About update the status of a pending fee
+func (w WorkerRepository) UpdatePendingFee(ctx context.Context, tx port.Tx, pendingFee *model.PendingFee) (int64, error){
+	childLogger.Debug().Str("func","UpdatePendingFee").Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "database.UpdatePendingFee")
+	defer span.End()
+
+	//Prepare
+	update_at := time.Now()
+	pendingFee.UpdateAt = &update_at
+
+	// Execute e Query
+	query := `UPDATE debit_pending_fee
+				SET status = $2,
+					attempts = $3,
+					next_attempt_at = $4,
+					last_error = $5,
+					updated_at = $6
+				WHERE id = $1`
+
+	row, err := pgTx(tx).Exec(ctx, query,	pendingFee.ID,
+									pendingFee.Status,
+									pendingFee.Attempts,
+									pendingFee.NextAttemptAt,
+									pendingFee.LastError,
+									pendingFee.UpdateAt)
+	if err != nil {
+		return 0, errors.New(err.Error())
+	}
+
+	return row.RowsAffected(), nil
+}
+
+// About cancel the pending fees of an account statement (the statement was reversed)
+// A fee being charged by the worker is locked, the update waits and then does not match (status CHARGED)
+func (w WorkerRepository) CancelPendingFee(ctx context.Context, tx port.Tx, accountStatement *model.AccountStatement) (int64, error){
+	childLogger.Info().Str("func","CancelPendingFee").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "database.CancelPendingFee")
+	defer span.End()
+
+	// Execute e Query
+	query := `UPDATE debit_pending_fee
+				SET status = 'CANCELLED',
+					updated_at = $2
+				WHERE fk_account_statement_id = $1
+				and status = 'PENDING'
+				and tenant_id = $3`
+
+	row, err := pgTx(tx).Exec(ctx, query, accountStatement.ID, time.Now(), tenantID(ctx))
+	if err != nil {
+		return 0, errors.New(err.Error())
+	}
+
+	return row.RowsAffected(), nil
+}
+
+// About update the fee status of an account statement
+func (w WorkerRepository) UpdateFeeStatus(ctx context.Context, tx port.Tx, accountStatement *model.AccountStatement) (int64, error){
+	childLogger.Debug().Str("func","UpdateFeeStatus").Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "database.UpdateFeeStatus")
+	defer span.End()
+
+	// Execute e Query
+	query := `UPDATE account_statement
+				SET fee_status = $2
+				WHERE id = $1
+				and tenant_id = $3`
+
+	row, err := pgTx(tx).Exec(ctx, query, accountStatement.ID, accountStatement.FeeStatus, tenantID(ctx))
+	if err != nil {
+		return 0, errors.New(err.Error())
+	}
+
+	return row.RowsAffected(), nil
+}
+
+// About get an account statement by id
+func (w WorkerRepository) GetAccountStatement(ctx context.Context, tx port.Tx, accountStatement *model.AccountStatement) (*model.AccountStatement, error){
+	childLogger.Debug().Str("func","GetAccountStatement").Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "database.GetAccountStatement")
+	defer span.End()
+
+	// Prepare
+	res_accountStatement := model.AccountStatement{}
+
+	// Query e Execute
+	query := `SELECT id, 
+					fk_account_id, 
+					type_charge,
+					charged_at,
+					currency, 
+					amount,
+					tenant_id,
+					transaction_id,
+					coalesce(fee_status, '')
+				FROM account_statement 
+				WHERE id = $1
+				and tenant_id = $2`
+
+	row := pgTx(tx).QueryRow(ctx, query, accountStatement.ID, tenantID(ctx))
+	err := row.Scan(&res_accountStatement.ID, 
+					&res_accountStatement.FkAccountID, 
+					&res_accountStatement.Type, 
+					&res_accountStatement.ChargeAt,
+					&res_accountStatement.Currency,
+					&res_accountStatement.Amount,
+					&res_accountStatement.TenantID,
+					&res_accountStatement.TransactionID,
+					&res_accountStatement.FeeStatus)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, erro.ErrNotFound
+		}
+		return nil, errors.New(err.Error())
+	}
+
+	return &res_accountStatement, nil
+}
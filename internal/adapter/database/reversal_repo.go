@@ -0,0 +1,284 @@
+package database
+
+import (
+	"context"
+	"time"
+	"errors"
+
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/port"
+	"github.com/go-debit/internal/core/erro"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// About get a debit by transaction id
+// The row is locked until the end of the transaction so concurrent reversals are serialized
+func (w WorkerRepository) GetDebitForUpdate(ctx context.Context, tx port.Tx, debit *model.AccountStatement) (*model.AccountStatement, error){
+	childLogger.Info().Str("func","GetDebitForUpdate").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "database.GetDebitForUpdate")
+	defer span.End()
+
+	// Prepare
+	res_accountStatement := model.AccountStatement{}
+
+	// Query e Execute
+	query := `SELECT id, 
+					fk_account_id, 
+					type_charge,
+					charged_at,
+					currency, 
+					amount,																										
+					tenant_id,
+					transaction_id	
+				FROM account_statement 
+				WHERE transaction_id = $1
+				and type_charge = $2
+				and tenant_id = $3
+				FOR UPDATE`
+
+	row := pgTx(tx).QueryRow(ctx, query, debit.TransactionID, debit.Type, tenantID(ctx))
+	err := row.Scan(&res_accountStatement.ID, 
+					&res_accountStatement.FkAccountID, 
+					&res_accountStatement.Type, 
+					&res_accountStatement.ChargeAt,
+					&res_accountStatement.Currency,
+					&res_accountStatement.Amount,
+					&res_accountStatement.TenantID,
+					&res_accountStatement.TransactionID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, erro.ErrNotFound
+		}
+		return nil, errors.New(err.Error())
+	}
+
+	return &res_accountStatement, nil
+}
+
+// About sum the amount already reversed for a debit
+func (w WorkerRepository) GetReversedAmount(ctx context.Context, tx port.Tx, debit *model.AccountStatement) (decimal.Decimal, error){
+	childLogger.Info().Str("func","GetReversedAmount").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "database.GetReversedAmount")
+	defer span.End()
+
+	// Query e Execute
+	query := `SELECT coalesce(sum(amount), 0)
+				FROM account_statement 
+				WHERE reversed_statement_id = $1
+				and type_charge = 'REVERSAL'
+				and tenant_id = $2`
+
+	var amount decimal.Decimal
+	if err := pgTx(tx).QueryRow(ctx, query, debit.ID, tenantID(ctx)).Scan(&amount); err != nil {
+		return decimal.Zero, errors.New(err.Error())
+	}
+
+	return amount, nil
+}
+
+// About add a reversal linked to the original debit
+func (w WorkerRepository) AddReversal(ctx context.Context, tx port.Tx, reversal *model.AccountStatement) (*model.AccountStatement, error){
+	childLogger.Info().Str("func","AddReversal").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "database.AddReversal")
+	defer span.End()
+
+	//Prepare
+	reversal.ChargeAt = time.Now()
+
+	// Execute e Query
+	query := `INSERT INTO account_statement (fk_account_id, 
+											type_charge,
+											charged_at, 
+											currency,
+											amount,
+											tenant_id,
+											transaction_id,
+											reversed_statement_id,
+											subject) 
+			 VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`
+
+	row := pgTx(tx).QueryRow(ctx, query,	reversal.FkAccountID, 
+									reversal.Type, 
+									reversal.ChargeAt, 
+									reversal.Currency, 
+									reversal.Amount, 
+									reversal.TenantID, 
+									reversal.TransactionID,
+									reversal.ReversedStatementID,
+									reversal.Subject)
+	var id int
+	if err := row.Scan(&id); err != nil {
+		return nil, errors.New(err.Error())
+	}
+
+	reversal.ID = id
+
+	return reversal, nil
+}
+
+// About list the fees charged over an account statement
+func (w WorkerRepository) ListAccountStatementFee(ctx context.Context, tx port.Tx, accountStatement *model.AccountStatement) (*[]model.AccountStatementFee, error){
+	childLogger.Info().Str("func","ListAccountStatementFee").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "database.ListAccountStatementFee")
+	defer span.End()
+
+	// Prepare
+	res_accountStatementFee := model.AccountStatementFee{}
+	res_accountStatementFee_list := []model.AccountStatementFee{}
+
+	// Query e Execute
+	query := `SELECT id, 
+					fk_account_statement_id,
+					charged_at,
+					type_fee,
+					value_fee,
+					currency,
+					amount,
+					tenant_id
+				FROM account_statement_fee 
+				WHERE fk_account_statement_id = $1
+				and tenant_id = $2
+				order by id`
+
+	rows, err := pgTx(tx).Query(ctx, query, accountStatement.ID, tenantID(ctx))
+	if err != nil {
+		return nil, errors.New(err.Error())
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan( 	&res_accountStatementFee.ID, 
+							&res_accountStatementFee.FkAccountStatementID, 
+							&res_accountStatementFee.ChargeAt,
+							&res_accountStatementFee.TypeFee, 
+							&res_accountStatementFee.ValueFee,
+							&res_accountStatementFee.Currency,
+							&res_accountStatementFee.Amount,
+							&res_accountStatementFee.TenantID,
+						)
+		if err != nil {
+			return nil, errors.New(err.Error())
+        }
+		res_accountStatementFee_list = append(res_accountStatementFee_list, res_accountStatementFee)
+	}
+	
+	return &res_accountStatementFee_list , nil
+}
+
+// About list the fees of the reversals of a debit (the fees already reversed)
+func (w WorkerRepository) ListReversedFee(ctx context.Context, tx port.Tx, debit *model.AccountStatement) (*[]model.AccountStatementFee, error){
+	childLogger.Info().Str("func","ListReversedFee").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "database.ListReversedFee")
+	defer span.End()
+
+	// Prepare
+	res_accountStatementFee := model.AccountStatementFee{}
+	res_accountStatementFee_list := []model.AccountStatementFee{}
+
+	// Query e Execute
+	query := `SELECT f.id, 
+					f.fk_account_statement_id,
+					f.charged_at,
+					f.type_fee,
+					f.value_fee,
+					f.currency,
+					f.amount,
+					f.tenant_id
+				FROM account_statement_fee f
+				JOIN account_statement s on s.id = f.fk_account_statement_id
+				WHERE s.reversed_statement_id = $1
+				and s.type_charge = 'REVERSAL'
+				and s.tenant_id = $2
+				and f.tenant_id = $2
+				order by f.id`
+
+	rows, err := pgTx(tx).Query(ctx, query, debit.ID, tenantID(ctx))
+	if err != nil {
+		return nil, errors.New(err.Error())
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan( 	&res_accountStatementFee.ID, 
+							&res_accountStatementFee.FkAccountStatementID, 
+							&res_accountStatementFee.ChargeAt,
+							&res_accountStatementFee.TypeFee, 
+							&res_accountStatementFee.ValueFee,
+							&res_accountStatementFee.Currency,
+							&res_accountStatementFee.Amount,
+							&res_accountStatementFee.TenantID,
+						)
+		if err != nil {
+			return nil, errors.New(err.Error())
+        }
+		res_accountStatementFee_list = append(res_accountStatementFee_list, res_accountStatementFee)
+	}
+	
+	return &res_accountStatementFee_list , nil
+}
+
+// About get a statement by transaction id and type
+func (w WorkerRepository) GetDebit(ctx context.Context, debit *model.AccountStatement) (*model.AccountStatement, error){
+	childLogger.Info().Str("func","GetDebit").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "database.GetDebit")
+	defer span.End()
+
+	conn, err := w.DatabasePGServer.Acquire(ctx)
+	if err != nil {
+		return nil, errors.New(err.Error())
+	}
+	defer w.DatabasePGServer.Release(conn)
+
+	// Prepare
+	res_accountStatement := model.AccountStatement{}
+
+	// Query e Execute
+	query := `SELECT id, 
+					fk_account_id, 
+					type_charge,
+					charged_at,
+					currency, 
+					amount,																										
+					tenant_id,
+					transaction_id,
+					coalesce(fee_status, ''),
+					coalesce(subject, '')
+				FROM account_statement 
+				WHERE transaction_id = $1
+				and type_charge = $2
+				and tenant_id = $3`
+
+	row := conn.QueryRow(ctx, query, debit.TransactionID, debit.Type, tenantID(ctx))
+	err = row.Scan(&res_accountStatement.ID, 
+					&res_accountStatement.FkAccountID, 
+					&res_accountStatement.Type, 
+					&res_accountStatement.ChargeAt,
+					&res_accountStatement.Currency,
+					&res_accountStatement.Amount,
+					&res_accountStatement.TenantID,
+					&res_accountStatement.TransactionID,
+					&res_accountStatement.FeeStatus,
+					&res_accountStatement.Subject)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, erro.ErrNotFound
+		}
+		return nil, errors.New(err.Error())
+	}
+
+	return &res_accountStatement, nil
+}
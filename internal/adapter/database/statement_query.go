@@ -0,0 +1,167 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"context"
+	"errors"
+
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/erro"
+)
+
+// sort options accepted by the search (sort parameter => column)
+var statementSortColumns = map[string]string{
+	"charged_at":	"charged_at",
+	"amount":		"amount",
+	"currency":		"currency",
+	"type_charge":	"type_charge",
+}
+
+// About a small builder for the account_statement search
+type statementQuery struct {
+	conditions	[]string
+	args		[]interface{}
+}
+
+// About add a condition, the %d of the condition receives the bind position of the arg
+func (q *statementQuery) where(condition string, arg interface{}) {
+	q.args = append(q.args, arg)
+	q.conditions = append(q.conditions, fmt.Sprintf(condition, len(q.args)))
+}
+
+// About bind an arg without a condition (limit, offset)
+func (q *statementQuery) bind(arg interface{}) string {
+	q.args = append(q.args, arg)
+	return fmt.Sprintf("$%d", len(q.args))
+}
+
+// About build the where clause from the filter (always scoped by the tenant of the request)
+func newStatementQuery(filter *model.StatementFilter, tenantID string) *statementQuery {
+	q := statementQuery{}
+
+	q.where("tenant_id = $%d", tenantID)
+	if filter.FkAccountID != 0 {
+		q.where("fk_account_id = $%d", filter.FkAccountID)
+	}
+	if filter.Type != "" {
+		q.where("type_charge = $%d", filter.Type)
+	}
+	if filter.DateStart != nil {
+		q.where("charged_at >= $%d", *filter.DateStart)
+	}
+	if filter.DateEnd != nil {
+		q.where("charged_at < $%d", *filter.DateEnd)
+	}
+	if filter.AmountMin != nil {
+		q.where("amount >= $%d", *filter.AmountMin)
+	}
+	if filter.AmountMax != nil {
+		q.where("amount <= $%d", *filter.AmountMax)
+	}
+	if filter.Currency != "" {
+		q.where("currency = $%d", filter.Currency)
+	}
+	if filter.TransactionID != "" {
+		q.where("transaction_id::text = $%d", filter.TransactionID)
+	}
+
+	return &q
+}
+
+// About the order by clause, id is always the tie breaker
+func statementOrderBy(filter *model.StatementFilter) (string, error) {
+	column := "charged_at"
+	if filter.Sort != "" {
+		c, ok := statementSortColumns[filter.Sort]
+		if !ok {
+			return "", erro.ErrInvalidFilter
+		}
+		column = c
+	}
+
+	order := "desc"
+	switch strings.ToLower(filter.Order) {
+	case "":
+	case "asc", "desc":
+		order = strings.ToLower(filter.Order)
+	default:
+		return "", erro.ErrInvalidFilter
+	}
+
+	return fmt.Sprintf("order by %s %s, id %s", column, order, order), nil
+}
+
+// About search the account statements with the filters informed
+// As ListDebit one extra row is read to detect if there is a next page
+func (w WorkerRepository) SearchStatement(ctx context.Context, filter *model.StatementFilter, pagination *model.Pagination) (*[]model.AccountStatement, error){
+	childLogger.Info().Str("func","SearchStatement").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Send()
+	
+	// Trace
+	span := tracerProvider.Span(ctx, "database.SearchStatement")
+	defer span.End()
+
+	conn, err := w.DatabasePGServer.Acquire(ctx)
+	if err != nil {
+		return nil, errors.New(err.Error())
+	}
+	defer w.DatabasePGServer.Release(conn)
+
+	// Prepare
+	res_accountStatement := model.AccountStatement{}
+	res_accountStatement_list := []model.AccountStatement{}
+
+	q := newStatementQuery(filter, tenantID(ctx))
+	orderBy, err := statementOrderBy(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	where := ""
+	if len(q.conditions) > 0 {
+		where = "WHERE " + strings.Join(q.conditions, " and ")
+	}
+
+	// Query e Execute
+	query := `SELECT id, 
+					fk_account_id, 
+					type_charge,
+					charged_at,
+					currency, 
+					amount,																										
+					tenant_id,
+					transaction_id,
+					reversed_statement_id,
+					coalesce(fee_status, '')
+				FROM account_statement ` + where + ` 
+				` + orderBy + ` 
+				limit ` + q.bind(pagination.Limit + 1) + ` offset ` + q.bind(filter.Offset)
+
+	tracerProvider.Event(span, query)
+
+	rows, err := conn.Query(ctx, query, q.args...)
+	if err != nil {
+		return nil, errors.New(err.Error())
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan( 	&res_accountStatement.ID, 
+							&res_accountStatement.FkAccountID, 
+							&res_accountStatement.Type, 
+							&res_accountStatement.ChargeAt,
+							&res_accountStatement.Currency,
+							&res_accountStatement.Amount,
+							&res_accountStatement.TenantID,
+							&res_accountStatement.TransactionID,
+							&res_accountStatement.ReversedStatementID,
+							&res_accountStatement.FeeStatus,
+						)
+		if err != nil {
+			return nil, errors.New(err.Error())
+        }
+		res_accountStatement_list = append(res_accountStatement_list, res_accountStatement)
+	}
+	
+	return &res_accountStatement_list , nil
+}
@@ -0,0 +1,58 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/go-debit/internal/core/model"
+	go_core_observ "github.com/eliezerraj/go-core/observability"
+	go_core_kafka "github.com/eliezerraj/go-core/event/kafka"
+)
+
+var childLogger = log.With().Str("component","go-debit").Str("package","internal.adapter.event").Logger()
+
+var tracerProvider go_core_observ.TracerProvider
+
+type KafkaPublisher struct {
+	topic			string
+	producerWorker	*go_core_kafka.ProducerWorker
+}
+
+// About create a publisher of the domain events in a kafka topic
+func NewKafkaPublisher(eventConfig *model.EventConfig) (*KafkaPublisher, error) {
+	childLogger.Info().Str("func","NewKafkaPublisher").Str("topic", eventConfig.Topic).Send()
+
+	var producerWorker go_core_kafka.ProducerWorker
+	producer, err := producerWorker.NewProducerWorker(eventConfig.KafkaConfigurations)
+	if err != nil {
+		return nil, errors.New(err.Error())
+	}
+
+	return &KafkaPublisher{	topic: eventConfig.Topic,
+							producerWorker: producer,
+	}, nil
+}
+
+// About publish a domain event (the key keeps the order of the events of the same key)
+func (k *KafkaPublisher) Publish(ctx context.Context, key string, domainEvent *model.DomainEvent) error {
+	childLogger.Info().Str("func","Publish").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Str("type", domainEvent.Type).Str("id", domainEvent.ID).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "event.Publish")
+	defer span.End()
+
+	payload, err := json.Marshal(domainEvent)
+	if err != nil {
+		return errors.New(err.Error())
+	}
+
+	err = k.producerWorker.Producer(ctx, k.topic, key, &domainEvent.TraceID, payload)
+	if err != nil {
+		return errors.New(err.Error())
+	}
+
+	return nil
+}
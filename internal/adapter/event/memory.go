@@ -0,0 +1,48 @@
+package event
+
+import (
+	"context"
+	"sync"
+
+	"github.com/go-debit/internal/core/model"
+)
+
+type MemoryPublisher struct {
+	mutex	sync.Mutex
+	events	[]model.DomainEvent
+}
+
+// About create a publisher that keeps the domain events in memory (tests and local runs)
+func NewMemoryPublisher() *MemoryPublisher {
+	childLogger.Info().Str("func","NewMemoryPublisher").Send()
+
+	return &MemoryPublisher{}
+}
+
+// About keep the domain event
+func (m *MemoryPublisher) Publish(ctx context.Context, key string, domainEvent *model.DomainEvent) error {
+	childLogger.Info().Str("func","Publish").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Str("type", domainEvent.Type).Str("id", domainEvent.ID).Send()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.events = append(m.events, *domainEvent)
+
+	return nil
+}
+
+// About get a copy of the published domain events
+func (m *MemoryPublisher) Events() []model.DomainEvent {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return append([]model.DomainEvent(nil), m.events...)
+}
+
+// About remove the published domain events
+func (m *MemoryPublisher) Reset() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.events = nil
+}
@@ -0,0 +1,194 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/go-debit/internal/core/service"
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/erro"
+	"github.com/go-debit/internal/adapter/grpc/proto"
+	go_core_observ "github.com/eliezerraj/go-core/observability"
+)
+
+var childLogger = log.With().Str("component", "go-debit").Str("package", "internal.adapter.grpc").Logger()
+
+var tracerProvider go_core_observ.TracerProvider
+
+type GrpcHandler struct {
+	proto.UnimplementedDebitServiceServer
+	workerService 	*service.WorkerService
+}
+
+func NewGrpcHandler(workerService *service.WorkerService) *GrpcHandler {
+	childLogger.Info().Str("func","NewGrpcHandler").Send()
+
+	return &GrpcHandler{
+		workerService: workerService,
+	}
+}
+
+// About map the erro of the service to a grpc status (the same statuses of the REST api)
+func errorStatus(err error) error {
+	switch err {
+	case erro.ErrNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case erro.ErrServiceUnavailable:
+		return status.Error(codes.Unavailable, err.Error())
+	case erro.ErrTransInvalid, erro.ErrInvalidAmount, erro.ErrIdempotencyConflict:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case erro.ErrInsufficientFunds, erro.ErrDebitLimitExceeded:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case erro.ErrInvalidCursor, erro.ErrInvalidLimit, erro.ErrIdempotencyKey, erro.ErrBadRequest:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case erro.ErrUnauthorized:
+		return status.Error(codes.Unauthenticated, err.Error())
+	case erro.ErrHTTPForbiden:
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func toProto(accountStatement *model.AccountStatement) *proto.AccountStatement {
+	res := proto.AccountStatement{
+		Id: int32(accountStatement.ID),
+		FkAccountId: int32(accountStatement.FkAccountID),
+		AccountId: accountStatement.AccountID,
+		TypeCharge: accountStatement.Type,
+		ChargedAt: timestamppb.New(accountStatement.ChargeAt),
+		Currency: accountStatement.Currency,
+		Amount: accountStatement.Amount.String(),
+		TenantId: accountStatement.TenantID,
+		Obs: accountStatement.Obs,
+		FeeStatus: accountStatement.FeeStatus,
+	}
+	if accountStatement.TransactionID != nil {
+		res.TransactionId = *accountStatement.TransactionID
+	}
+	return &res
+}
+
+func toProtoFee(accountStatementFee *model.AccountStatementFee) *proto.AccountStatementFee {
+	return &proto.AccountStatementFee{
+		Id: int32(accountStatementFee.ID),
+		FkAccountStatementId: int32(accountStatementFee.FkAccountStatementID),
+		TypeFee: accountStatementFee.TypeFee,
+		ValueFee: accountStatementFee.ValueFee.String(),
+		ChargedAt: timestamppb.New(accountStatementFee.ChargeAt),
+		Currency: accountStatementFee.Currency,
+		Amount: accountStatementFee.Amount.String(),
+		TenantId: accountStatementFee.TenantID,
+	}
+}
+
+func toProtoPage(accountStatementPage *model.AccountStatementPage) *proto.ListDebitResponse {
+	res := proto.ListDebitResponse{NextCursor: accountStatementPage.NextCursor}
+	for i := range accountStatementPage.Data {
+		res.Data = append(res.Data, toProto(&accountStatementPage.Data[i]))
+	}
+	return &res
+}
+
+func (h *GrpcHandler) AddDebit(ctx context.Context, req *proto.AddDebitRequest) (*proto.AddDebitResponse, error) {
+	childLogger.Info().Str("func","AddDebit").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Send()
+
+	//trace
+	span := tracerProvider.Span(ctx, "adapter.grpc.AddDebit")
+	defer span.End()
+
+	if req.GetDebit() == nil {
+		return nil, status.Error(codes.InvalidArgument, "debit is required")
+	}
+	amount, err := decimal.NewFromString(req.GetDebit().GetAmount())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, erro.ErrInvalidAmount.Error())
+	}
+	if len(req.GetIdempotencyKey()) > 255 {
+		return nil, errorStatus(erro.ErrIdempotencyKey)
+	}
+
+	debit := model.AccountStatement{
+		AccountID: req.GetDebit().GetAccountId(),
+		Type: req.GetDebit().GetTypeCharge(),
+		Currency: req.GetDebit().GetCurrency(),
+		Amount: amount,
+		TenantID: req.GetDebit().GetTenantId(),
+	}
+
+	res, err := h.workerService.AddDebit(ctx, &debit, req.GetIdempotencyKey())
+	if err != nil {
+		return nil, errorStatus(err)
+	}
+
+	return &proto.AddDebitResponse{Debit: toProto(res)}, nil
+}
+
+func (h *GrpcHandler) ListDebit(ctx context.Context, req *proto.ListDebitRequest) (*proto.ListDebitResponse, error) {
+	childLogger.Info().Str("func","ListDebit").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Send()
+
+	//trace
+	span := tracerProvider.Span(ctx, "adapter.grpc.ListDebit")
+	defer span.End()
+
+	debit := model.AccountStatement{AccountID: req.GetAccountId()}
+	pagination := model.Pagination{Limit: int(req.GetLimit()), Cursor: req.GetCursor()}
+
+	res, err := h.workerService.ListDebit(ctx, &debit, &pagination)
+	if err != nil {
+		return nil, errorStatus(err)
+	}
+
+	return toProtoPage(res), nil
+}
+
+func (h *GrpcHandler) ListDebitPerDate(ctx context.Context, req *proto.ListDebitPerDateRequest) (*proto.ListDebitResponse, error) {
+	childLogger.Info().Str("func","ListDebitPerDate").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Send()
+
+	//trace
+	span := tracerProvider.Span(ctx, "adapter.grpc.ListDebitPerDate")
+	defer span.End()
+
+	if req.GetDateStart() == nil {
+		return nil, status.Error(codes.InvalidArgument, "date_start is required")
+	}
+
+	debit := model.AccountStatement{AccountID: req.GetAccountId(), ChargeAt: req.GetDateStart().AsTime()}
+	pagination := model.Pagination{Limit: int(req.GetLimit()), Cursor: req.GetCursor()}
+
+	res, err := h.workerService.ListDebitPerDate(ctx, &debit, &pagination)
+	if err != nil {
+		return nil, errorStatus(err)
+	}
+
+	return toProtoPage(res), nil
+}
+
+func (h *GrpcHandler) GetDebit(ctx context.Context, req *proto.GetDebitRequest) (*proto.GetDebitResponse, error) {
+	childLogger.Info().Str("func","GetDebit").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Send()
+
+	//trace
+	span := tracerProvider.Span(ctx, "adapter.grpc.GetDebit")
+	defer span.End()
+
+	transactionID := req.GetTransactionId()
+	debit := model.AccountStatement{TransactionID: &transactionID}
+
+	res, err := h.workerService.GetDebit(ctx, &debit)
+	if err != nil {
+		return nil, errorStatus(err)
+	}
+
+	res_debit := proto.GetDebitResponse{Debit: toProto(res)}
+	for i := range res.Fees {
+		res_debit.Fees = append(res_debit.Fees, toProtoFee(&res.Fees[i]))
+	}
+
+	return &res_debit, nil
+}
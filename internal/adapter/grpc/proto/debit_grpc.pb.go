@@ -0,0 +1,239 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: debit.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	DebitService_AddDebit_FullMethodName         = "/debit.v1.DebitService/AddDebit"
+	DebitService_ListDebit_FullMethodName        = "/debit.v1.DebitService/ListDebit"
+	DebitService_ListDebitPerDate_FullMethodName = "/debit.v1.DebitService/ListDebitPerDate"
+	DebitService_GetDebit_FullMethodName         = "/debit.v1.DebitService/GetDebit"
+)
+
+// DebitServiceClient is the client API for DebitService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Debits of the account statement, the same operations of the REST api
+type DebitServiceClient interface {
+	AddDebit(ctx context.Context, in *AddDebitRequest, opts ...grpc.CallOption) (*AddDebitResponse, error)
+	ListDebit(ctx context.Context, in *ListDebitRequest, opts ...grpc.CallOption) (*ListDebitResponse, error)
+	ListDebitPerDate(ctx context.Context, in *ListDebitPerDateRequest, opts ...grpc.CallOption) (*ListDebitResponse, error)
+	GetDebit(ctx context.Context, in *GetDebitRequest, opts ...grpc.CallOption) (*GetDebitResponse, error)
+}
+
+type debitServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDebitServiceClient(cc grpc.ClientConnInterface) DebitServiceClient {
+	return &debitServiceClient{cc}
+}
+
+func (c *debitServiceClient) AddDebit(ctx context.Context, in *AddDebitRequest, opts ...grpc.CallOption) (*AddDebitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddDebitResponse)
+	err := c.cc.Invoke(ctx, DebitService_AddDebit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debitServiceClient) ListDebit(ctx context.Context, in *ListDebitRequest, opts ...grpc.CallOption) (*ListDebitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDebitResponse)
+	err := c.cc.Invoke(ctx, DebitService_ListDebit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debitServiceClient) ListDebitPerDate(ctx context.Context, in *ListDebitPerDateRequest, opts ...grpc.CallOption) (*ListDebitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDebitResponse)
+	err := c.cc.Invoke(ctx, DebitService_ListDebitPerDate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debitServiceClient) GetDebit(ctx context.Context, in *GetDebitRequest, opts ...grpc.CallOption) (*GetDebitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDebitResponse)
+	err := c.cc.Invoke(ctx, DebitService_GetDebit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DebitServiceServer is the server API for DebitService service.
+// All implementations must embed UnimplementedDebitServiceServer
+// for forward compatibility.
+//
+// Debits of the account statement, the same operations of the REST api
+type DebitServiceServer interface {
+	AddDebit(context.Context, *AddDebitRequest) (*AddDebitResponse, error)
+	ListDebit(context.Context, *ListDebitRequest) (*ListDebitResponse, error)
+	ListDebitPerDate(context.Context, *ListDebitPerDateRequest) (*ListDebitResponse, error)
+	GetDebit(context.Context, *GetDebitRequest) (*GetDebitResponse, error)
+	mustEmbedUnimplementedDebitServiceServer()
+}
+
+// UnimplementedDebitServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDebitServiceServer struct{}
+
+func (UnimplementedDebitServiceServer) AddDebit(context.Context, *AddDebitRequest) (*AddDebitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddDebit not implemented")
+}
+func (UnimplementedDebitServiceServer) ListDebit(context.Context, *ListDebitRequest) (*ListDebitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDebit not implemented")
+}
+func (UnimplementedDebitServiceServer) ListDebitPerDate(context.Context, *ListDebitPerDateRequest) (*ListDebitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDebitPerDate not implemented")
+}
+func (UnimplementedDebitServiceServer) GetDebit(context.Context, *GetDebitRequest) (*GetDebitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDebit not implemented")
+}
+func (UnimplementedDebitServiceServer) mustEmbedUnimplementedDebitServiceServer() {}
+func (UnimplementedDebitServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeDebitServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DebitServiceServer will
+// result in compilation errors.
+type UnsafeDebitServiceServer interface {
+	mustEmbedUnimplementedDebitServiceServer()
+}
+
+func RegisterDebitServiceServer(s grpc.ServiceRegistrar, srv DebitServiceServer) {
+	// If the following call pancis, it indicates UnimplementedDebitServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DebitService_ServiceDesc, srv)
+}
+
+func _DebitService_AddDebit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddDebitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebitServiceServer).AddDebit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebitService_AddDebit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebitServiceServer).AddDebit(ctx, req.(*AddDebitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebitService_ListDebit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDebitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebitServiceServer).ListDebit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebitService_ListDebit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebitServiceServer).ListDebit(ctx, req.(*ListDebitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebitService_ListDebitPerDate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDebitPerDateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebitServiceServer).ListDebitPerDate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebitService_ListDebitPerDate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebitServiceServer).ListDebitPerDate(ctx, req.(*ListDebitPerDateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebitService_GetDebit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDebitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebitServiceServer).GetDebit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebitService_GetDebit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebitServiceServer).GetDebit(ctx, req.(*GetDebitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DebitService_ServiceDesc is the grpc.ServiceDesc for DebitService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DebitService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "debit.v1.DebitService",
+	HandlerType: (*DebitServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddDebit",
+			Handler:    _DebitService_AddDebit_Handler,
+		},
+		{
+			MethodName: "ListDebit",
+			Handler:    _DebitService_ListDebit_Handler,
+		},
+		{
+			MethodName: "ListDebitPerDate",
+			Handler:    _DebitService_ListDebitPerDate_Handler,
+		},
+		{
+			MethodName: "GetDebit",
+			Handler:    _DebitService_GetDebit_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "debit.proto",
+}
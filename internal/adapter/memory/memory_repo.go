@@ -0,0 +1,181 @@
+package memory
+
+import (
+	"fmt"
+	"context"
+	"sync"
+	"sync/atomic"
+	"errors"
+
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/port"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+)
+
+var childLogger = log.With().Str("component","go-debit").Str("package","internal.adapter.memory").Logger()
+
+var errTxClosed = errors.New("tx is closed")
+
+// About the tables of the repository
+type memoryData struct {
+	statements		[]model.AccountStatement
+	fees			[]model.AccountStatementFee
+	idempotencyKeys	map[idempotencyMapKey]model.IdempotencyKey
+	outbox			[]model.Outbox
+	pendingFees		[]model.PendingFee
+	overdraftLimits	map[overdraftKey]decimal.Decimal
+}
+
+// About the key of an idempotency key (the keys of each tenant are independent like the pg table)
+type idempotencyMapKey struct {
+	tenantID	string
+	key			string
+}
+
+// About the key of an overdraft limit (the limit of an account is scoped by the tenant like the pg table)
+type overdraftKey struct {
+	tenantID	string
+	fkAccountID	int
+}
+
+// About the tenant of the request (set by the tenant middleware), the tenant data is scoped by it like the pg repository
+func tenantID(ctx context.Context) string {
+	if ctx.Value("tenant-id") == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v",ctx.Value("tenant-id"))
+}
+
+// About copy the tables (the snapshot of a transaction)
+func (d *memoryData) clone() *memoryData {
+	c := memoryData{
+		statements: append([]model.AccountStatement(nil), d.statements...),
+		fees: append([]model.AccountStatementFee(nil), d.fees...),
+		idempotencyKeys: make(map[idempotencyMapKey]model.IdempotencyKey, len(d.idempotencyKeys)),
+		outbox: append([]model.Outbox(nil), d.outbox...),
+		pendingFees: append([]model.PendingFee(nil), d.pendingFees...),
+		overdraftLimits: make(map[overdraftKey]decimal.Decimal, len(d.overdraftLimits)),
+	}
+	for k, v := range d.idempotencyKeys {
+		c.idempotencyKeys[k] = v
+	}
+	for k, v := range d.overdraftLimits {
+		c.overdraftLimits[k] = v
+	}
+	return &c
+}
+
+// About an in-memory repository with the same semantics of the pg repository (for the tests of the service)
+// The transactions run one at a time over a snapshot of the tables, the commit publishes the snapshot
+// and the rollback discards it, so the account lock, FOR UPDATE and SKIP LOCKED are always honored
+type MemoryRepository struct {
+	txMutex		sync.Mutex
+	mutex		sync.RWMutex
+	data		*memoryData
+
+	// the sequences are not rolled back (like the pg serial)
+	statementSeq	atomic.Int64
+	feeSeq			atomic.Int64
+	outboxSeq		atomic.Int64
+	pendingFeeSeq	atomic.Int64
+}
+
+// About a transaction of the in-memory repository
+type memoryTransaction struct {
+	repository	*MemoryRepository
+	data		*memoryData
+	closed		bool
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	childLogger.Info().Str("func","NewMemoryRepository").Send()
+
+	return &MemoryRepository{
+		data: &memoryData{	idempotencyKeys: map[idempotencyMapKey]model.IdempotencyKey{},
+							overdraftLimits: map[overdraftKey]decimal.Decimal{} },
+	}
+}
+
+// About the memory is always reachable
+func (r *MemoryRepository) Ping(ctx context.Context) error {
+	return nil
+}
+
+// About start a transaction (waits until the running transaction ends)
+func (r *MemoryRepository) StartTx(ctx context.Context) (port.Tx, error) {
+	r.txMutex.Lock()
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return &memoryTransaction{repository: r, data: r.data.clone()}, nil
+}
+
+// About release the transaction, a transaction not closed is rolled back
+func (r *MemoryRepository) ReleaseTx(tx port.Tx) {
+	if !tx.(*memoryTransaction).closed {
+		tx.Rollback(context.Background())
+	}
+}
+
+// About publish the snapshot of the transaction
+func (t *memoryTransaction) Commit(ctx context.Context) error {
+	if t.closed {
+		return errTxClosed
+	}
+	t.closed = true
+
+	t.repository.mutex.Lock()
+	t.repository.data = t.data
+	t.repository.mutex.Unlock()
+
+	t.repository.txMutex.Unlock()
+	return nil
+}
+
+// About discard the snapshot of the transaction
+func (t *memoryTransaction) Rollback(ctx context.Context) error {
+	if t.closed {
+		return errTxClosed
+	}
+	t.closed = true
+
+	t.repository.txMutex.Unlock()
+	return nil
+}
+
+// About the tables of a transaction
+func txData(tx port.Tx) *memoryData {
+	return tx.(*memoryTransaction).data
+}
+
+// About read the committed tables
+func (r *MemoryRepository) read(f func(data *memoryData)) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	f(r.data)
+}
+
+// About set the overdraft limit of an account of a tenant (the debit_overdraft_limit table is maintained outside go-debit)
+func (r *MemoryRepository) SetOverdraftLimit(tenantID string, fkAccountID int, limit decimal.Decimal) {
+	r.txMutex.Lock()
+	defer r.txMutex.Unlock()
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.data.overdraftLimits[overdraftKey{tenantID: tenantID, fkAccountID: fkAccountID}] = limit
+}
+
+// About get the committed outbox messages (used by the tests to check the postings and the events)
+func (r *MemoryRepository) ListOutbox() []model.Outbox {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return append([]model.Outbox(nil), r.data.outbox...)
+}
+
+var _ port.WorkerRepository = (*MemoryRepository)(nil)
@@ -0,0 +1,274 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/port"
+	"github.com/go-debit/internal/core/erro"
+
+	"github.com/shopspring/decimal"
+)
+
+// About check the statement has a balance posting not yet delivered to go-account (pending or failed)
+func undeliveredPosting(data *memoryData, accountStatementID int) bool {
+	for _, outbox := range data.outbox {
+		if outbox.FkAccountStatementID == accountStatementID && outbox.EventType == "ACCOUNT_BALANCE_POSTING" && outbox.Status != "DELIVERED" {
+			return true
+		}
+	}
+	return false
+}
+
+// About lock the account until the end of the transaction (the transactions already run one at a time)
+func (r *MemoryRepository) LockAccount(ctx context.Context, tx port.Tx, fkAccountID int) error{
+	childLogger.Debug().Str("func","LockAccount").Int("fk_account_id", fkAccountID).Send()
+
+	return nil
+}
+
+// About sum the statements of the account not yet posted to the account balance (pending in the outbox)
+func (r *MemoryRepository) GetPendingPostingAmount(ctx context.Context, tx port.Tx, fkAccountID int) (decimal.Decimal, error){
+	childLogger.Debug().Str("func","GetPendingPostingAmount").Int("fk_account_id", fkAccountID).Send()
+
+	data := txData(tx)
+	amount := decimal.Zero
+	for _, row := range data.statements {
+		if row.FkAccountID == fkAccountID && row.TenantID == tenantID(ctx) && undeliveredPosting(data, row.ID) {
+			amount = amount.Add(row.Amount)
+		}
+	}
+
+	return amount, nil
+}
+
+// About sum the statements of the account since a date already posted to the account balance
+func (r *MemoryRepository) GetPostedAmountSince(ctx context.Context, fkAccountID int, dateStart time.Time) (decimal.Decimal, error){
+	childLogger.Debug().Str("func","GetPostedAmountSince").Int("fk_account_id", fkAccountID).Send()
+
+	amount := decimal.Zero
+	r.read(func(data *memoryData) {
+		for _, row := range data.statements {
+			if row.FkAccountID == fkAccountID && row.TenantID == tenantID(ctx) && !row.ChargeAt.Before(dateStart) && !undeliveredPosting(data, row.ID) {
+				amount = amount.Add(row.Amount)
+			}
+		}
+	})
+
+	return amount, nil
+}
+
+// About get the overdraft limit of an account (overrides the limit of the tenant)
+func (r *MemoryRepository) GetOverdraftLimit(ctx context.Context, tx port.Tx, fkAccountID int) (*model.OverdraftPolicy, error){
+	childLogger.Debug().Str("func","GetOverdraftLimit").Int("fk_account_id", fkAccountID).Send()
+
+	limit, ok := txData(tx).overdraftLimits[overdraftKey{tenantID: tenantID(ctx), fkAccountID: fkAccountID}]
+	if !ok {
+		return nil, erro.ErrNotFound
+	}
+
+	return &model.OverdraftPolicy{CheckFunds: true, Limit: limit}, nil
+}
+
+// About sum the debits of the account in the day and in the month of the limit periods
+func (r *MemoryRepository) GetDebitUsage(ctx context.Context, tx port.Tx, fkAccountID int, debitLimit *model.DebitLimit) (*model.DebitLimit, error){
+	childLogger.Debug().Str("func","GetDebitUsage").Int("fk_account_id", fkAccountID).Send()
+
+	debitLimit.Daily.UsedAmount, debitLimit.Daily.UsedCount = decimal.Zero, 0
+	debitLimit.Monthly.UsedAmount, debitLimit.Monthly.UsedCount = decimal.Zero, 0
+
+	// the amounts of the debits are negative
+	for _, row := range txData(tx).statements {
+		if row.FkAccountID != fkAccountID || row.TenantID != tenantID(ctx) || row.Type != "DEBIT" || row.ChargeAt.Before(debitLimit.Monthly.PeriodStart) {
+			continue
+		}
+		debitLimit.Monthly.UsedAmount = debitLimit.Monthly.UsedAmount.Sub(row.Amount)
+		debitLimit.Monthly.UsedCount = debitLimit.Monthly.UsedCount + 1
+		if !row.ChargeAt.Before(debitLimit.Daily.PeriodStart) {
+			debitLimit.Daily.UsedAmount = debitLimit.Daily.UsedAmount.Sub(row.Amount)
+			debitLimit.Daily.UsedCount = debitLimit.Daily.UsedCount + 1
+		}
+	}
+
+	return debitLimit, nil
+}
+
+// About reserve an idempotency key
+func (r *MemoryRepository) AddIdempotencyKey(ctx context.Context, tx port.Tx, idempotencyKey *model.IdempotencyKey) (*model.IdempotencyKey, error){
+	childLogger.Debug().Str("func","AddIdempotencyKey").Send()
+
+	data := txData(tx)
+	mapKey := idempotencyMapKey{tenantID: idempotencyKey.TenantID, key: idempotencyKey.Key}
+	if _, ok := data.idempotencyKeys[mapKey]; ok {
+		return nil, erro.ErrDuplicateKey
+	}
+
+	idempotencyKey.CreateAt = time.Now()
+	data.idempotencyKeys[mapKey] = model.IdempotencyKey{	Key: idempotencyKey.Key,
+																	RequestHash: idempotencyKey.RequestHash,
+																	TenantID: idempotencyKey.TenantID,
+																	CreateAt: idempotencyKey.CreateAt }
+
+	return idempotencyKey, nil
+}
+
+// About get an idempotency key already stored
+func (r *MemoryRepository) GetIdempotencyKey(ctx context.Context, tx port.Tx, idempotencyKey *model.IdempotencyKey) (*model.IdempotencyKey, error){
+	childLogger.Debug().Str("func","GetIdempotencyKey").Send()
+
+	res_idempotencyKey, ok := txData(tx).idempotencyKeys[idempotencyMapKey{tenantID: tenantID(ctx), key: idempotencyKey.Key}]
+	if !ok {
+		return nil, erro.ErrNotFound
+	}
+
+	return &res_idempotencyKey, nil
+}
+
+// About store the response replayed for an idempotency key
+func (r *MemoryRepository) UpdateIdempotencyKey(ctx context.Context, tx port.Tx, idempotencyKey *model.IdempotencyKey) (int64, error){
+	childLogger.Debug().Str("func","UpdateIdempotencyKey").Send()
+
+	data := txData(tx)
+	mapKey := idempotencyMapKey{tenantID: tenantID(ctx), key: idempotencyKey.Key}
+	row, ok := data.idempotencyKeys[mapKey]
+	if !ok {
+		return 0, nil
+	}
+
+	update_at := time.Now()
+	idempotencyKey.UpdateAt = &update_at
+
+	row.StatusCode = idempotencyKey.StatusCode
+	row.Response = idempotencyKey.Response
+	row.UpdateAt = idempotencyKey.UpdateAt
+	data.idempotencyKeys[mapKey] = row
+
+	return 1, nil
+}
+
+// About add a message in the outbox (same transaction of the account statement)
+func (r *MemoryRepository) AddOutbox(ctx context.Context, tx port.Tx, outbox *model.Outbox) (*model.Outbox, error){
+	childLogger.Debug().Str("func","AddOutbox").Send()
+
+	outbox.CreateAt = time.Now()
+	outbox.NextAttemptAt = outbox.CreateAt
+	outbox.Status = "PENDING"
+	outbox.ID = int(r.outboxSeq.Add(1))
+
+	data := txData(tx)
+	data.outbox = append(data.outbox, *outbox)
+
+	return outbox, nil
+}
+
+// About list the pending messages ready to be delivered
+func (r *MemoryRepository) ListPendingOutbox(ctx context.Context, tx port.Tx, limit int) (*[]model.Outbox, error){
+	childLogger.Debug().Str("func","ListPendingOutbox").Send()
+
+	now := time.Now()
+	res_outbox_list := []model.Outbox{}
+	for _, row := range txData(tx).outbox {
+		if len(res_outbox_list) == limit {
+			break
+		}
+		if row.Status == "PENDING" && !row.NextAttemptAt.After(now) {
+			res_outbox_list = append(res_outbox_list, row)
+		}
+	}
+
+	return &res_outbox_list, nil
+}
+
+// About update the delivery status of a message
+func (r *MemoryRepository) UpdateOutbox(ctx context.Context, tx port.Tx, outbox *model.Outbox) (int64, error){
+	childLogger.Debug().Str("func","UpdateOutbox").Send()
+
+	data := txData(tx)
+	for i := range data.outbox {
+		if data.outbox[i].ID == outbox.ID {
+			data.outbox[i].Status = outbox.Status
+			data.outbox[i].Attempts = outbox.Attempts
+			data.outbox[i].NextAttemptAt = outbox.NextAttemptAt
+			data.outbox[i].LastError = outbox.LastError
+			data.outbox[i].DeliveredAt = outbox.DeliveredAt
+			return 1, nil
+		}
+	}
+
+	return 0, nil
+}
+
+// About add a pending fee (the fees of the statement could not be calculated)
+func (r *MemoryRepository) AddPendingFee(ctx context.Context, tx port.Tx, pendingFee *model.PendingFee) (*model.PendingFee, error){
+	childLogger.Debug().Str("func","AddPendingFee").Send()
+
+	pendingFee.CreateAt = time.Now()
+	pendingFee.NextAttemptAt = pendingFee.CreateAt
+	pendingFee.Status = "PENDING"
+	pendingFee.ID = int(r.pendingFeeSeq.Add(1))
+
+	data := txData(tx)
+	data.pendingFees = append(data.pendingFees, *pendingFee)
+
+	return pendingFee, nil
+}
+
+// About list the pending fees ready to be charged
+func (r *MemoryRepository) ListPendingFee(ctx context.Context, tx port.Tx, limit int) (*[]model.PendingFee, error){
+	childLogger.Debug().Str("func","ListPendingFee").Send()
+
+	now := time.Now()
+	res_pendingFee_list := []model.PendingFee{}
+	for _, row := range txData(tx).pendingFees {
+		if len(res_pendingFee_list) == limit {
+			break
+		}
+		if row.Status == "PENDING" && !row.NextAttemptAt.After(now) {
+			res_pendingFee_list = append(res_pendingFee_list, row)
+		}
+	}
+
+	return &res_pendingFee_list, nil
+}
+
+// About update the status of a pending fee
+func (r *MemoryRepository) UpdatePendingFee(ctx context.Context, tx port.Tx, pendingFee *model.PendingFee) (int64, error){
+	childLogger.Debug().Str("func","UpdatePendingFee").Send()
+
+	update_at := time.Now()
+	pendingFee.UpdateAt = &update_at
+
+	data := txData(tx)
+	for i := range data.pendingFees {
+		if data.pendingFees[i].ID == pendingFee.ID {
+			data.pendingFees[i].Status = pendingFee.Status
+			data.pendingFees[i].Attempts = pendingFee.Attempts
+			data.pendingFees[i].NextAttemptAt = pendingFee.NextAttemptAt
+			data.pendingFees[i].LastError = pendingFee.LastError
+			data.pendingFees[i].UpdateAt = pendingFee.UpdateAt
+			return 1, nil
+		}
+	}
+
+	return 0, nil
+}
+
+// About cancel the pending fees of an account statement (the statement was reversed)
+func (r *MemoryRepository) CancelPendingFee(ctx context.Context, tx port.Tx, accountStatement *model.AccountStatement) (int64, error){
+	childLogger.Debug().Str("func","CancelPendingFee").Send()
+
+	update_at := time.Now()
+	rows := int64(0)
+
+	data := txData(tx)
+	for i := range data.pendingFees {
+		if data.pendingFees[i].FkAccountStatementID == accountStatement.ID && data.pendingFees[i].Status == "PENDING" && data.pendingFees[i].TenantID == tenantID(ctx) {
+			data.pendingFees[i].Status = "CANCELLED"
+			data.pendingFees[i].UpdateAt = &update_at
+			rows = rows + 1
+		}
+	}
+
+	return rows, nil
+}
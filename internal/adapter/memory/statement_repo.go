@@ -0,0 +1,359 @@
+package memory
+
+import (
+	"context"
+	"time"
+	"slices"
+	"strings"
+	"cmp"
+
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/port"
+	"github.com/go-debit/internal/core/erro"
+
+	"github.com/shopspring/decimal"
+)
+
+// About compare the transaction ids (the pointers are never compared)
+func sameTransactionID(a *string, b *string) bool {
+	return a != nil && b != nil && *a == *b
+}
+
+// About order by charged_at desc, id desc (the order of the lists)
+func compareChargeAtDesc(a model.AccountStatement, b model.AccountStatement) int {
+	if c := b.ChargeAt.Compare(a.ChargeAt); c != 0 {
+		return c
+	}
+	return cmp.Compare(b.ID, a.ID)
+}
+
+// About the keyset of the pagination, (charged_at, id) < (pagination.ChargeAt, pagination.ID)
+func beforeKeyset(accountStatement model.AccountStatement, pagination *model.Pagination) bool {
+	if pagination.ChargeAt == nil {
+		return true
+	}
+	if c := accountStatement.ChargeAt.Compare(*pagination.ChargeAt); c != 0 {
+		return c < 0
+	}
+	return accountStatement.ID < pagination.ID
+}
+
+// About add a statement in the transaction
+func (r *MemoryRepository) addStatement(tx port.Tx, accountStatement *model.AccountStatement) *model.AccountStatement {
+	accountStatement.ChargeAt = time.Now()
+	accountStatement.ID = int(r.statementSeq.Add(1))
+
+	row := *accountStatement
+	row.AccountID = ""
+	row.Obs = ""
+	row.Fees = nil
+
+	data := txData(tx)
+	data.statements = append(data.statements, row)
+
+	return accountStatement
+}
+
+// About add debit
+func (r *MemoryRepository) AddDebit(ctx context.Context, tx port.Tx, debit *model.AccountStatement) (*model.AccountStatement, error){
+	childLogger.Debug().Str("func","AddDebit").Send()
+
+	debit.ReversedStatementID = nil
+
+	return r.addStatement(tx, debit), nil
+}
+
+// About add a reversal linked to the original debit
+func (r *MemoryRepository) AddReversal(ctx context.Context, tx port.Tx, reversal *model.AccountStatement) (*model.AccountStatement, error){
+	childLogger.Debug().Str("func","AddReversal").Send()
+
+	reversal.FeeStatus = ""
+
+	return r.addStatement(tx, reversal), nil
+}
+
+// About get a statement of the tenant by transaction id and type
+func findDebit(data *memoryData, debit *model.AccountStatement, tenantID string) (*model.AccountStatement, error) {
+	for _, accountStatement := range data.statements {
+		if sameTransactionID(accountStatement.TransactionID, debit.TransactionID) && accountStatement.Type == debit.Type && accountStatement.TenantID == tenantID {
+			return &accountStatement, nil
+		}
+	}
+	return nil, erro.ErrNotFound
+}
+
+// About get a statement by transaction id and type
+func (r *MemoryRepository) GetDebit(ctx context.Context, debit *model.AccountStatement) (res_accountStatement *model.AccountStatement, err error){
+	childLogger.Debug().Str("func","GetDebit").Send()
+
+	r.read(func(data *memoryData) {
+		res_accountStatement, err = findDebit(data, debit, tenantID(ctx))
+	})
+
+	return res_accountStatement, err
+}
+
+// About get a debit by transaction id (the transactions already run one at a time)
+func (r *MemoryRepository) GetDebitForUpdate(ctx context.Context, tx port.Tx, debit *model.AccountStatement) (*model.AccountStatement, error){
+	childLogger.Debug().Str("func","GetDebitForUpdate").Send()
+
+	return findDebit(txData(tx), debit, tenantID(ctx))
+}
+
+// About get an account statement by id
+func (r *MemoryRepository) GetAccountStatement(ctx context.Context, tx port.Tx, accountStatement *model.AccountStatement) (*model.AccountStatement, error){
+	childLogger.Debug().Str("func","GetAccountStatement").Send()
+
+	for _, row := range txData(tx).statements {
+		if row.ID == accountStatement.ID && row.TenantID == tenantID(ctx) {
+			return &row, nil
+		}
+	}
+
+	return nil, erro.ErrNotFound
+}
+
+// About sum the amount already reversed for a debit
+func (r *MemoryRepository) GetReversedAmount(ctx context.Context, tx port.Tx, debit *model.AccountStatement) (decimal.Decimal, error){
+	childLogger.Debug().Str("func","GetReversedAmount").Send()
+
+	amount := decimal.Zero
+	for _, row := range txData(tx).statements {
+		if row.Type == "REVERSAL" && row.ReversedStatementID != nil && *row.ReversedStatementID == debit.ID && row.TenantID == tenantID(ctx) {
+			amount = amount.Add(row.Amount)
+		}
+	}
+
+	return amount, nil
+}
+
+// About update the fee status of an account statement
+func (r *MemoryRepository) UpdateFeeStatus(ctx context.Context, tx port.Tx, accountStatement *model.AccountStatement) (int64, error){
+	childLogger.Debug().Str("func","UpdateFeeStatus").Send()
+
+	data := txData(tx)
+	for i := range data.statements {
+		if data.statements[i].ID == accountStatement.ID && data.statements[i].TenantID == tenantID(ctx) {
+			data.statements[i].FeeStatus = accountStatement.FeeStatus
+			return 1, nil
+		}
+	}
+
+	return 0, nil
+}
+
+// About list the debits of an account in a period (keyset pagination, one extra row like the pg repository)
+// An empty type lists the debits and the reversals
+func (r *MemoryRepository) listDebit(tenantID string, debit *model.AccountStatement, dateStart *time.Time, dateEnd *time.Time, pagination *model.Pagination) *[]model.AccountStatement {
+	res_accountStatement_list := []model.AccountStatement{}
+	r.read(func(data *memoryData) {
+		for _, row := range data.statements {
+			if row.FkAccountID != debit.FkAccountID || row.TenantID != tenantID || (debit.Type != "" && row.Type != debit.Type) || !beforeKeyset(row, pagination) {
+				continue
+			}
+			if dateStart != nil && row.ChargeAt.Before(*dateStart) {
+				continue
+			}
+			if dateEnd != nil && !row.ChargeAt.Before(*dateEnd) {
+				continue
+			}
+			res_accountStatement_list = append(res_accountStatement_list, row)
+		}
+	})
+
+	slices.SortFunc(res_accountStatement_list, compareChargeAtDesc)
+	if len(res_accountStatement_list) > pagination.Limit + 1 {
+		res_accountStatement_list = res_accountStatement_list[:pagination.Limit + 1]
+	}
+
+	return &res_accountStatement_list
+}
+
+// About list the debits of an account
+func (r *MemoryRepository) ListDebit(ctx context.Context, debit *model.AccountStatement, pagination *model.Pagination) (*[]model.AccountStatement, error){
+	childLogger.Debug().Str("func","ListDebit").Send()
+
+	return r.listDebit(tenantID(ctx), debit, nil, nil, pagination), nil
+}
+
+// About list the debits of an account since a date (and before dateEnd when informed), paginated like ListDebit
+func (r *MemoryRepository) ListDebitPerDate(ctx context.Context, debit *model.AccountStatement, dateEnd *time.Time, pagination *model.Pagination) (*[]model.AccountStatement, error){
+	childLogger.Debug().Str("func","ListDebitPerDate").Send()
+
+	return r.listDebit(tenantID(ctx), debit, &debit.ChargeAt, dateEnd, pagination), nil
+}
+
+// About list all the statements (debits and reversals) of the account in a period, oldest first
+func (r *MemoryRepository) ListStatementPerPeriod(ctx context.Context, fkAccountID int, dateStart time.Time, dateEnd time.Time) (*[]model.AccountStatement, error){
+	childLogger.Debug().Str("func","ListStatementPerPeriod").Send()
+
+	res_accountStatement_list := []model.AccountStatement{}
+	r.read(func(data *memoryData) {
+		for _, row := range data.statements {
+			if row.FkAccountID == fkAccountID && row.TenantID == tenantID(ctx) && !row.ChargeAt.Before(dateStart) && row.ChargeAt.Before(dateEnd) {
+				res_accountStatement_list = append(res_accountStatement_list, row)
+			}
+		}
+	})
+
+	slices.SortFunc(res_accountStatement_list, func(a model.AccountStatement, b model.AccountStatement) int {
+		return compareChargeAtDesc(b, a)
+	})
+
+	return &res_accountStatement_list, nil
+}
+
+// About check the statement of the tenant matches the filter of the search
+func matchStatementFilter(row model.AccountStatement, filter *model.StatementFilter, tenantID string) bool {
+	switch {
+	case row.TenantID != tenantID:
+		return false
+	case filter.FkAccountID != 0 && row.FkAccountID != filter.FkAccountID:
+		return false
+	case filter.Type != "" && row.Type != filter.Type:
+		return false
+	case filter.DateStart != nil && row.ChargeAt.Before(*filter.DateStart):
+		return false
+	case filter.DateEnd != nil && !row.ChargeAt.Before(*filter.DateEnd):
+		return false
+	case filter.AmountMin != nil && row.Amount.LessThan(*filter.AmountMin):
+		return false
+	case filter.AmountMax != nil && row.Amount.GreaterThan(*filter.AmountMax):
+		return false
+	case filter.Currency != "" && row.Currency != filter.Currency:
+		return false
+	case filter.TransactionID != "" && (row.TransactionID == nil || *row.TransactionID != filter.TransactionID):
+		return false
+	}
+	return true
+}
+
+// About the order of the search (sort parameter => column), id is always the tie breaker
+func statementCompare(filter *model.StatementFilter) (func(a model.AccountStatement, b model.AccountStatement) int, error) {
+	var compareColumn func(a model.AccountStatement, b model.AccountStatement) int
+	switch filter.Sort {
+	case "", "charged_at":
+		compareColumn = func(a model.AccountStatement, b model.AccountStatement) int { return a.ChargeAt.Compare(b.ChargeAt) }
+	case "amount":
+		compareColumn = func(a model.AccountStatement, b model.AccountStatement) int { return a.Amount.Cmp(b.Amount) }
+	case "currency":
+		compareColumn = func(a model.AccountStatement, b model.AccountStatement) int { return cmp.Compare(a.Currency, b.Currency) }
+	case "type_charge":
+		compareColumn = func(a model.AccountStatement, b model.AccountStatement) int { return cmp.Compare(a.Type, b.Type) }
+	default:
+		return nil, erro.ErrInvalidFilter
+	}
+
+	direction := -1
+	switch strings.ToLower(filter.Order) {
+	case "", "desc":
+	case "asc":
+		direction = 1
+	default:
+		return nil, erro.ErrInvalidFilter
+	}
+
+	return func(a model.AccountStatement, b model.AccountStatement) int {
+		if c := compareColumn(a, b); c != 0 {
+			return c * direction
+		}
+		return cmp.Compare(a.ID, b.ID) * direction
+	}, nil
+}
+
+// About search the account statements with the filters informed
+// As ListDebit one extra row is read to detect if there is a next page
+func (r *MemoryRepository) SearchStatement(ctx context.Context, filter *model.StatementFilter, pagination *model.Pagination) (*[]model.AccountStatement, error){
+	childLogger.Debug().Str("func","SearchStatement").Send()
+
+	compare, err := statementCompare(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	res_accountStatement_list := []model.AccountStatement{}
+	r.read(func(data *memoryData) {
+		for _, row := range data.statements {
+			if matchStatementFilter(row, filter, tenantID(ctx)) {
+				res_accountStatement_list = append(res_accountStatement_list, row)
+			}
+		}
+	})
+
+	slices.SortFunc(res_accountStatement_list, compare)
+	res_accountStatement_list = res_accountStatement_list[min(filter.Offset, len(res_accountStatement_list)):]
+	if len(res_accountStatement_list) > pagination.Limit + 1 {
+		res_accountStatement_list = res_accountStatement_list[:pagination.Limit + 1]
+	}
+
+	return &res_accountStatement_list, nil
+}
+
+// About add a fee of an account statement
+func (r *MemoryRepository) AddAccountStatementFee(ctx context.Context, tx port.Tx, accountStatementFee model.AccountStatementFee) (*model.AccountStatementFee, error){
+	childLogger.Debug().Str("func","AddAccountStatementFee").Send()
+
+	accountStatementFee.ChargeAt = time.Now()
+	accountStatementFee.ID = int(r.feeSeq.Add(1))
+
+	data := txData(tx)
+	data.fees = append(data.fees, accountStatementFee)
+
+	return &accountStatementFee, nil
+}
+
+// About list the fees charged over an account statement
+func (r *MemoryRepository) ListAccountStatementFee(ctx context.Context, tx port.Tx, accountStatement *model.AccountStatement) (*[]model.AccountStatementFee, error){
+	childLogger.Debug().Str("func","ListAccountStatementFee").Send()
+
+	res_accountStatementFee_list := []model.AccountStatementFee{}
+	for _, row := range txData(tx).fees {
+		if row.FkAccountStatementID == accountStatement.ID && row.TenantID == tenantID(ctx) {
+			res_accountStatementFee_list = append(res_accountStatementFee_list, row)
+		}
+	}
+
+	return &res_accountStatementFee_list, nil
+}
+
+// About list the fees of the reversals of a debit
+func (r *MemoryRepository) ListReversedFee(ctx context.Context, tx port.Tx, debit *model.AccountStatement) (*[]model.AccountStatementFee, error){
+	childLogger.Debug().Str("func","ListReversedFee").Send()
+
+	data := txData(tx)
+	reversalIDs := []int{}
+	for _, row := range data.statements {
+		if row.Type == "REVERSAL" && row.ReversedStatementID != nil && *row.ReversedStatementID == debit.ID && row.TenantID == tenantID(ctx) {
+			reversalIDs = append(reversalIDs, row.ID)
+		}
+	}
+
+	res_accountStatementFee_list := []model.AccountStatementFee{}
+	for _, row := range data.fees {
+		if slices.Contains(reversalIDs, row.FkAccountStatementID) && row.TenantID == tenantID(ctx) {
+			res_accountStatementFee_list = append(res_accountStatementFee_list, row)
+		}
+	}
+
+	return &res_accountStatementFee_list, nil
+}
+
+// About list the fees of a set of statements
+func (r *MemoryRepository) ListAccountStatementFeePerStatement(ctx context.Context, accountStatementIDs []int) (*[]model.AccountStatementFee, error){
+	childLogger.Debug().Str("func","ListAccountStatementFeePerStatement").Send()
+
+	res_accountStatementFee_list := []model.AccountStatementFee{}
+	r.read(func(data *memoryData) {
+		for _, row := range data.fees {
+			if slices.Contains(accountStatementIDs, row.FkAccountStatementID) && row.TenantID == tenantID(ctx) {
+				res_accountStatementFee_list = append(res_accountStatementFee_list, row)
+			}
+		}
+	})
+
+	slices.SortStableFunc(res_accountStatementFee_list, func(a model.AccountStatementFee, b model.AccountStatementFee) int {
+		return cmp.Compare(a.FkAccountStatementID, b.FkAccountStatementID)
+	})
+
+	return &res_accountStatementFee_list, nil
+}
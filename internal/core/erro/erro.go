@@ -9,8 +9,29 @@ var (
 	ErrInsert 			= errors.New("insert data error")
 	ErrUnmarshal 		= errors.New("unmarshal json error")
 	ErrUnauthorized 	= errors.New("not authorized")
+	ErrBadRequest		= errors.New("bad request")
 	ErrServer		 	= errors.New("server identified error")
 	ErrHTTPForbiden		= errors.New("forbiden request")
 	ErrTransInvalid		= errors.New("transaction invalid")
 	ErrInvalidAmount	= errors.New("invalid amount for this transaction type")
+	ErrDuplicateKey		= errors.New("duplicate key")
+	ErrIdempotencyKey	= errors.New("idempotency key invalid")
+	ErrIdempotencyConflict	= errors.New("idempotency key already used with a different request")
+	ErrAlreadyReversed	= errors.New("transaction already reversed")
+	ErrReversalExceeded	= errors.New("reversal amount exceeds the original transaction")
+	ErrInvalidCursor	= errors.New("invalid pagination cursor")
+	ErrInvalidLimit		= errors.New("invalid pagination limit")
+	ErrInvalidFilter	= errors.New("invalid search filter")
+	ErrServiceUnavailable	= errors.New("service unavailable")
+	ErrInvalidAction	= errors.New("invalid action")
+	ErrInsufficientFunds	= errors.New("insufficient funds")
+	ErrDebitLimitExceeded	= errors.New("debit limit exceeded")
+	ErrInvalidBatch		= errors.New("invalid batch size")
+	ErrBatchConcurrency	= errors.New("batch concurrency must be below the max connections of the database pool")
+	ErrInvalidFormat	= errors.New("invalid file format")
+	ErrEventPublisher	= errors.New("event publisher not configured")
+	ErrSchemaVersion	= errors.New("database schema version is behind the migrations")
+	ErrInvalidMigration	= errors.New("invalid migration")
+	ErrInvalidToken		= errors.New("invalid token")
+	ErrJwks			= errors.New("jwks without valid keys")
 )
\ No newline at end of file
@@ -0,0 +1,141 @@
+package model
+
+import (
+	"encoding/xml"
+)
+
+// About ISO 20022 camt.053.001.08 (BankToCustomerStatementV08), only the elements filled by go-debit
+// The fields follow the order of the schema sequences
+
+const Camt053Namespace = "urn:iso:std:iso:20022:tech:xsd:camt.053.001.08"
+
+type Camt053Document struct {
+	XMLName			xml.Name				`xml:"Document"`
+	Xmlns			string					`xml:"xmlns,attr"`
+	BkToCstmrStmt	Camt053BkToCstmrStmt	`xml:"BkToCstmrStmt"`
+}
+
+type Camt053BkToCstmrStmt struct {
+	GrpHdr			Camt053GrpHdr		`xml:"GrpHdr"`
+	Stmt			Camt053Stmt			`xml:"Stmt"`
+}
+
+type Camt053GrpHdr struct {
+	MsgId			string				`xml:"MsgId"`
+	CreDtTm			string				`xml:"CreDtTm"`
+}
+
+type Camt053Stmt struct {
+	Id				string				`xml:"Id"`
+	CreDtTm			string				`xml:"CreDtTm"`
+	FrToDt			Camt053FrToDt		`xml:"FrToDt"`
+	Acct			Camt053Acct			`xml:"Acct"`
+	Bal				[]Camt053Bal		`xml:"Bal"`
+	TxsSummry		Camt053TxsSummry	`xml:"TxsSummry"`
+	Ntry			[]Camt053Ntry		`xml:"Ntry"`
+}
+
+type Camt053FrToDt struct {
+	FrDtTm			string				`xml:"FrDtTm"`
+	ToDtTm			string				`xml:"ToDtTm"`
+}
+
+type Camt053Acct struct {
+	Id				Camt053AcctId		`xml:"Id"`
+	Ccy				string				`xml:"Ccy,omitempty"`
+}
+
+type Camt053AcctId struct {
+	Othr			Camt053Othr			`xml:"Othr"`
+}
+
+type Camt053Othr struct {
+	Id				string				`xml:"Id"`
+}
+
+type Camt053Amt struct {
+	Ccy				string				`xml:"Ccy,attr"`
+	Value			string				`xml:",chardata"`
+}
+
+type Camt053Code struct {
+	Cd				string				`xml:"Cd"`
+}
+
+type Camt053BalTp struct {
+	CdOrPrtry		Camt053Code			`xml:"CdOrPrtry"`
+}
+
+type Camt053Dt struct {
+	Dt				string				`xml:"Dt,omitempty"`
+	DtTm			string				`xml:"DtTm,omitempty"`
+}
+
+type Camt053Bal struct {
+	Tp				Camt053BalTp		`xml:"Tp"`
+	Amt				Camt053Amt			`xml:"Amt"`
+	CdtDbtInd		string				`xml:"CdtDbtInd"`
+	Dt				Camt053Dt			`xml:"Dt"`
+}
+
+type Camt053TxsSummry struct {
+	TtlNtries		Camt053TtlNtries	`xml:"TtlNtries"`
+}
+
+type Camt053TtlNtries struct {
+	NbOfNtries		string				`xml:"NbOfNtries"`
+	Sum				string				`xml:"Sum"`
+	TtlNetNtry		Camt053TtlNetNtry	`xml:"TtlNetNtry"`
+}
+
+type Camt053TtlNetNtry struct {
+	Amt				string				`xml:"Amt"`
+	CdtDbtInd		string				`xml:"CdtDbtInd"`
+}
+
+type Camt053Ntry struct {
+	NtryRef			string				`xml:"NtryRef"`
+	Amt				Camt053Amt			`xml:"Amt"`
+	CdtDbtInd		string				`xml:"CdtDbtInd"`
+	RvslInd			bool				`xml:"RvslInd,omitempty"`
+	Sts				Camt053Code			`xml:"Sts"`
+	BookgDt			Camt053Dt			`xml:"BookgDt"`
+	ValDt			Camt053Dt			`xml:"ValDt"`
+	AcctSvcrRef		string				`xml:"AcctSvcrRef,omitempty"`
+	BkTxCd			Camt053BkTxCd		`xml:"BkTxCd"`
+	NtryDtls		*Camt053NtryDtls	`xml:"NtryDtls,omitempty"`
+	AddtlNtryInf	string				`xml:"AddtlNtryInf,omitempty"`
+}
+
+type Camt053BkTxCd struct {
+	Domn			*Camt053Domn		`xml:"Domn,omitempty"`
+	Prtry			*Camt053Prtry		`xml:"Prtry,omitempty"`
+}
+
+type Camt053Domn struct {
+	Cd				string				`xml:"Cd"`
+	Fmly			Camt053Fmly			`xml:"Fmly"`
+}
+
+type Camt053Fmly struct {
+	Cd				string				`xml:"Cd"`
+	SubFmlyCd		string				`xml:"SubFmlyCd"`
+}
+
+type Camt053Prtry struct {
+	Cd				string				`xml:"Cd"`
+	Issr			string				`xml:"Issr,omitempty"`
+}
+
+type Camt053NtryDtls struct {
+	TxDtls			[]Camt053TxDtls		`xml:"TxDtls"`
+}
+
+type Camt053TxDtls struct {
+	Refs			Camt053Refs			`xml:"Refs"`
+}
+
+type Camt053Refs struct {
+	AcctSvcrRef		string				`xml:"AcctSvcrRef,omitempty"`
+	EndToEndId		string				`xml:"EndToEndId,omitempty"`
+}
@@ -2,8 +2,11 @@ package model
 
 import (
 	"time"
+	"encoding/json"
+	"github.com/shopspring/decimal"
 	go_core_pg "github.com/eliezerraj/go-core/database/pg"
 	go_core_observ "github.com/eliezerraj/go-core/observability" 
+	go_core_kafka "github.com/eliezerraj/go-core/event/kafka"
 )
 
 type AppServer struct {
@@ -12,6 +15,20 @@ type AppServer struct {
 	ConfigOTEL		*go_core_observ.ConfigOTEL	`json:"otel_config"`
 	DatabaseConfig	*go_core_pg.DatabaseConfig  `json:"database"`
 	ApiService 		[]ApiService 				`json:"api_endpoints"`
+	MoneyConfig		*MoneyConfig				`json:"money"`
+	OutboxConfig	*OutboxConfig				`json:"outbox"`
+	PendingFeeConfig	*PendingFeeConfig		`json:"pending_fee"`
+	CacheConfig		*CacheConfig				`json:"cache"`
+	OverdraftConfig	*OverdraftConfig			`json:"overdraft"`
+	DebitLimitConfig	*DebitLimitConfig		`json:"debit_limit"`
+	BatchConfig		*BatchConfig				`json:"batch"`
+	EventConfig		*EventConfig				`json:"event"`
+	TenantConfig	*TenantConfig				`json:"tenant"`
+	JwtConfig		*JwtConfig					`json:"jwt"`
+	HealthConfig	*HealthConfig				`json:"health"`
+	MetricsConfig	*MetricsConfig				`json:"metrics"`
+	PayFeeCache		*CacheStats					`json:"payfee_cache,omitempty"`
+	CircuitBreakerConfig	[]CircuitBreakerConfig	`json:"circuit_breakers"`
 }
 
 type InfoPod struct {
@@ -31,6 +48,7 @@ type Server struct {
 	WriteTimeout	int `json:"writeTimeout"`
 	IdleTimeout		int `json:"idleTimeout"`
 	CtxTimeout		int `json:"ctxTimeout"`
+	GrpcPort		int `json:"grpc_port"`
 }
 
 type MessageRouter struct {
@@ -47,6 +65,18 @@ type Account struct {
 	UserLastUpdate	*string  	`json:"user_last_update,omitempty"`
 }
 
+type AccountBalance struct {
+	ID				int			`json:"id,omitempty"`
+	FkAccountID		int			`json:"fk_account_id,omitempty"`
+	AccountID		string		`json:"account_id,omitempty"`
+	Currency		string  	`json:"currency,omitempty"`
+	Amount			decimal.Decimal 	`json:"amount"`
+	TenantID		string  	`json:"tenant_id,omitempty"`
+	CreateAt		time.Time 	`json:"create_at,omitempty"`
+	UpdateAt		*time.Time 	`json:"update_at,omitempty"`
+	UserLastUpdate	*string  	`json:"user_last_update,omitempty"`
+}
+
 type AccountStatement struct {
 	ID				int			`json:"id,omitempty"`
 	FkAccountID		int			`json:"fk_account_id,omitempty"`
@@ -54,26 +84,30 @@ type AccountStatement struct {
 	Type			string  	`json:"type_charge,omitempty"`
 	ChargeAt		time.Time 	`json:"charged_at,omitempty"`
 	Currency		string  	`json:"currency,omitempty"`
-	Amount			float64 	`json:"amount,omitempty"`
+	Amount			decimal.Decimal 	`json:"amount"`
 	TenantID		string  	`json:"tenant_id,omitempty"`
 	Obs				string  	`json:"obs,omitempty"`
 	TransactionID	*string  	`json:"transaction_id,transaction_id"`
+	ReversedStatementID	*int	`json:"reversed_statement_id,omitempty"`
+	FeeStatus		string  	`json:"fee_status,omitempty"`
+	Subject			string  	`json:"subject,omitempty"`
+	Fees			[]AccountStatementFee	`json:"fees,omitempty"`
 }
 
 type AccountStatementFee struct {
 	ID				int			`json:"id,omitempty"`
 	FkAccountStatementID		 int `json:"fk_account_statement_id,omitempty"`
 	TypeFee			string  	`json:"type_fee,omitempty"`
-	ValueFee		float64  	`json:"value_fee,omitempty"`
+	ValueFee		decimal.Decimal  	`json:"value_fee"`
 	ChargeAt		time.Time 	`json:"charged_at,omitempty"`
 	Currency		string  	`json:"currency,omitempty"`
-	Amount			float64 	`json:"amount,omitempty"`
+	Amount			decimal.Decimal 	`json:"amount"`
 	TenantID		string  	`json:"tenant_id,omitempty"`
 }
 
 type Fee struct {
     Name 		string  `redis:"name" json:"name"`
-	Value		float64  `redis:"value" json:"value"`
+	Value		decimal.Decimal  `redis:"value" json:"value"`
 }
 
 type ScriptData struct {
@@ -91,4 +125,265 @@ type ApiService struct {
 	Url				string `json:"url"`
 	Method			string `json:"method"`
 	Header_x_apigw_api_id	string `json:"x-apigw-api-id"`
+}
+
+type IdempotencyKey struct {
+	Key				string		`json:"idempotency_key"`
+	RequestHash		string		`json:"request_hash"`
+	StatusCode		int			`json:"status_code,omitempty"`
+	Response		[]byte		`json:"response,omitempty"`
+	TenantID		string  	`json:"tenant_id,omitempty"`
+	CreateAt		time.Time 	`json:"create_at,omitempty"`
+	UpdateAt		*time.Time 	`json:"update_at,omitempty"`
+}
+
+type Pagination struct {
+	Limit			int			`json:"limit,omitempty"`
+	Cursor			string		`json:"cursor,omitempty"`
+	ChargeAt		*time.Time 	`json:"-"`
+	ID				int			`json:"-"`
+}
+
+type AccountStatementPage struct {
+	Data			[]AccountStatement	`json:"data"`
+	NextCursor		string		`json:"next_cursor,omitempty"`
+}
+
+type StatementFilter struct {
+	AccountID		string		`json:"account_id,omitempty"`
+	FkAccountID		int			`json:"fk_account_id,omitempty"`
+	Type			string  	`json:"type_charge,omitempty"`
+	DateStart		*time.Time 	`json:"date_start,omitempty"`
+	DateEnd			*time.Time 	`json:"date_end,omitempty"`
+	AmountMin		*decimal.Decimal 	`json:"amount_min,omitempty"`
+	AmountMax		*decimal.Decimal 	`json:"amount_max,omitempty"`
+	Currency		string  	`json:"currency,omitempty"`
+	TenantID		string  	`json:"tenant_id,omitempty"`
+	TransactionID	string  	`json:"transaction_id,omitempty"`
+	Sort			string  	`json:"sort,omitempty"`
+	Order			string  	`json:"order,omitempty"`
+	Offset			int			`json:"-"`
+}
+
+type MoneyConfig struct {
+	RoundingMode	string				`json:"rounding_mode"`
+	CurrencyScale	map[string]int32	`json:"currency_scale"`
+}
+
+type OutboxConfig struct {
+	PollInterval	int		`json:"poll_interval"`
+	BatchSize		int		`json:"batch_size"`
+	MaxAttempts		int		`json:"max_attempts"`
+}
+
+type Outbox struct {
+	ID						int			`json:"id,omitempty"`
+	FkAccountStatementID	int			`json:"fk_account_statement_id,omitempty"`
+	TransactionID			*string  	`json:"transaction_id,omitempty"`
+	EventType				string  	`json:"event_type,omitempty"`
+	Payload					[]byte  	`json:"payload,omitempty"`
+	Status					string  	`json:"status,omitempty"`
+	Attempts				int			`json:"attempts"`
+	NextAttemptAt			time.Time 	`json:"next_attempt_at,omitempty"`
+	LastError				*string  	`json:"last_error,omitempty"`
+	TraceID					string  	`json:"trace_id,omitempty"`
+	CreateAt				time.Time 	`json:"create_at,omitempty"`
+	DeliveredAt				*time.Time 	`json:"delivered_at,omitempty"`
+}
+
+type CircuitBreakerConfig struct {
+	Name				string	`json:"name"`
+	MaxRequests			uint32	`json:"max_requests"`
+	Interval			int		`json:"interval"`
+	Timeout				int		`json:"timeout"`
+	FailureThreshold	uint32	`json:"failure_threshold"`
+}
+
+type CircuitBreakerStatus struct {
+	Name					string	`json:"name"`
+	State					string	`json:"state"`
+	Forced					string	`json:"forced,omitempty"`
+	Requests				uint32	`json:"requests"`
+	TotalSuccesses			uint32	`json:"total_successes"`
+	TotalFailures			uint32	`json:"total_failures"`
+	ConsecutiveSuccesses	uint32	`json:"consecutive_successes"`
+	ConsecutiveFailures		uint32	`json:"consecutive_failures"`
+}
+
+type PendingFeeConfig struct {
+	PollInterval	int		`json:"poll_interval"`
+	BatchSize		int		`json:"batch_size"`
+	MaxAttempts		int		`json:"max_attempts"`
+}
+
+type PendingFee struct {
+	ID						int			`json:"id,omitempty"`
+	FkAccountStatementID	int			`json:"fk_account_statement_id,omitempty"`
+	Currency				string  	`json:"currency,omitempty"`
+	Amount					decimal.Decimal `json:"amount"`
+	TenantID				string  	`json:"tenant_id,omitempty"`
+	Status					string  	`json:"status,omitempty"`
+	Attempts				int			`json:"attempts"`
+	NextAttemptAt			time.Time 	`json:"next_attempt_at,omitempty"`
+	LastError				*string  	`json:"last_error,omitempty"`
+	TraceID					string  	`json:"trace_id,omitempty"`
+	CreateAt				time.Time 	`json:"create_at,omitempty"`
+	UpdateAt				*time.Time 	`json:"update_at,omitempty"`
+}
+
+type CacheConfig struct {
+	TTL				int		`json:"ttl"`
+	StaleTTL		int		`json:"stale_ttl"`
+}
+
+type CacheStats struct {
+	Entries			int		`json:"entries"`
+	Hits			uint64	`json:"hits"`
+	Misses			uint64	`json:"misses"`
+	StaleHits		uint64	`json:"stale_hits"`
+	TTL				int		`json:"ttl"`
+	StaleTTL		int		`json:"stale_ttl"`
+}
+
+type OverdraftPolicy struct {
+	CheckFunds		bool			`json:"check_funds"`
+	Limit			decimal.Decimal	`json:"limit"`
+}
+
+type OverdraftConfig struct {
+	Default			OverdraftPolicy				`json:"default"`
+	Tenant			map[string]OverdraftPolicy	`json:"tenant"`
+}
+
+type DebitLimitConfig struct {
+	DailyAmount		decimal.Decimal	`json:"daily_amount"`
+	DailyCount		int				`json:"daily_count"`
+	MonthlyAmount	decimal.Decimal	`json:"monthly_amount"`
+	MonthlyCount	int				`json:"monthly_count"`
+}
+
+type DebitLimitUsage struct {
+	PeriodStart		time.Time			`json:"period_start"`
+	PeriodEnd		time.Time			`json:"period_end"`
+	MaxAmount		*decimal.Decimal	`json:"max_amount,omitempty"`
+	MaxCount		*int				`json:"max_count,omitempty"`
+	UsedAmount		decimal.Decimal		`json:"used_amount"`
+	UsedCount		int					`json:"used_count"`
+	RemainingAmount	*decimal.Decimal	`json:"remaining_amount,omitempty"`
+	RemainingCount	*int				`json:"remaining_count,omitempty"`
+}
+
+type DebitLimit struct {
+	AccountID		string			`json:"account_id"`
+	Daily			DebitLimitUsage	`json:"daily"`
+	Monthly			DebitLimitUsage	`json:"monthly"`
+}
+
+type BatchConfig struct {
+	Concurrency		int		`json:"concurrency"`
+	MaxItems		int		`json:"max_items"`
+}
+
+type BatchDebitResult struct {
+	Index			int					`json:"index"`
+	Status			string				`json:"status"`
+	StatusCode		int					`json:"status_code"`
+	TransactionID	*string				`json:"transaction_id,omitempty"`
+	Error			string				`json:"error,omitempty"`
+	Debit			*AccountStatement	`json:"debit,omitempty"`
+	Err				error				`json:"-"`
+}
+
+type StatementExport struct {
+	AccountID		string			`json:"account_id"`
+	DateStart		time.Time		`json:"date_start"`
+	DateEnd			time.Time		`json:"date_end"`
+	Currency		string			`json:"currency,omitempty"`
+	Balance			*AccountBalance	`json:"balance,omitempty"`
+}
+
+// The tenant of a request comes from the header set by the authenticated gateway
+type TenantConfig struct {
+	Header			string		`json:"header"`
+}
+
+// The keys of the JWT come from a JWKS file or url (the url is refreshed after JwksRefresh seconds)
+type JwtConfig struct {
+	Enabled			bool		`json:"enabled"`
+	JwksFile		string		`json:"jwks_file,omitempty"`
+	JwksUrl			string		`json:"jwks_url,omitempty"`
+	JwksRefresh		int			`json:"jwks_refresh"`
+	Issuer			string		`json:"issuer,omitempty"`
+	Audience		string		`json:"audience"`
+	Leeway			int			`json:"leeway"`
+	TenantClaim		string		`json:"tenant_claim"`
+	PersonClaim		string		`json:"person_claim"`
+	OperatorRole	string		`json:"operator_role"`
+}
+
+// The caller authenticated by the JWT (the tenant is empty when the token has no tenant claim)
+type Identity struct {
+	Subject			string		`json:"subject"`
+	Scopes			[]string	`json:"scopes"`
+	TenantID		string		`json:"tenant_id,omitempty"`
+	PersonID		string		`json:"person_id,omitempty"`
+	Roles			[]string	`json:"roles"`
+}
+
+// The readiness (/health) checks the database and, when the url is set, go-account and go-payfee
+// A dependency of Critical down makes the pod not ready (503)
+type HealthConfig struct {
+	Timeout			int			`json:"timeout"`
+	AccountUrl		string		`json:"account_url,omitempty"`
+	PayFeeUrl		string		`json:"payfee_url,omitempty"`
+	Critical		[]string	`json:"critical"`
+}
+
+// The metrics are exposed in /metrics (Prometheus) and/or pushed every Interval seconds to the OTLP collector of the traces
+type MetricsConfig struct {
+	Prometheus		bool		`json:"prometheus"`
+	Otlp			bool		`json:"otlp"`
+	Interval		int			`json:"interval"`
+}
+
+type HealthStatus struct {
+	Status			string				`json:"status"`
+	Dependencies	[]DependencyStatus	`json:"dependencies"`
+}
+
+type DependencyStatus struct {
+	Name			string		`json:"name"`
+	Status			string		`json:"status"`
+	Critical		bool		`json:"critical"`
+	Latency			int64		`json:"latency_ms"`
+	Error			string		`json:"error,omitempty"`
+}
+
+type EventConfig struct {
+	Publisher		string		`json:"publisher"`
+	Topic			string		`json:"topic"`
+	Source			string		`json:"source"`
+	KafkaConfigurations	*go_core_kafka.KafkaConfigurations	`json:"-"`
+}
+
+// CloudEvents 1.0 envelope (structured mode), the version is the suffix of the type
+type DomainEvent struct {
+	SpecVersion		string		`json:"specversion"`
+	ID				string		`json:"id"`
+	Source			string		`json:"source"`
+	Type			string		`json:"type"`
+	Subject			string		`json:"subject,omitempty"`
+	Time			time.Time	`json:"time"`
+	DataContentType	string		`json:"datacontenttype"`
+	TransactionID	string		`json:"transactionid,omitempty"`
+	TenantID		string		`json:"tenantid,omitempty"`
+	TraceID			string		`json:"traceid,omitempty"`
+	Data			json.RawMessage	`json:"data"`
+}
+
+// A versioned migration of the database schema (applied_at is nil while pending)
+type SchemaMigration struct {
+	Version		int			`json:"version"`
+	Name		string		`json:"name"`
+	AppliedAt	*time.Time	`json:"applied_at,omitempty"`
 }
\ No newline at end of file
@@ -0,0 +1,24 @@
+package port
+
+import (
+	"context"
+
+	"github.com/go-debit/internal/core/model"
+)
+
+// About the go-account service (implemented by the http and the fake adapters)
+// The errors are the erro values (ErrNotFound, ErrUnauthorized, ErrHTTPForbiden, ErrServiceUnavailable, ErrServer)
+// PostBalance sends the idempotencyKey, so a posting delivered again (retry of the outbox) is applied once
+type AccountClient interface {
+	GetAccount(ctx context.Context, accountID string) (*model.Account, error)
+	GetAccountBalance(ctx context.Context, accountID string) (*model.AccountBalance, error)
+	PostBalance(ctx context.Context, accountStatement *model.AccountStatement, idempotencyKey string) error
+	Ping(ctx context.Context) error
+}
+
+// About the go-payfee service (implemented by the http and the fake adapters)
+type PayFeeClient interface {
+	GetScript(ctx context.Context, script string) (*model.Script, error)
+	GetFee(ctx context.Context, fee string) (*model.Fee, error)
+	Ping(ctx context.Context) error
+}
@@ -0,0 +1,69 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-debit/internal/core/model"
+
+	"github.com/shopspring/decimal"
+)
+
+// About a database transaction, each adapter has its own (the core only commits or rolls back)
+type Tx interface {
+	Commit(ctx context.Context) error
+	Rollback(ctx context.Context) error
+}
+
+// About the repository used by the WorkerService (implemented by the pg and the in-memory adapters)
+// The methods with a Tx run inside the transaction, the others read the committed data
+type WorkerRepository interface {
+	// health
+	Ping(ctx context.Context) error
+
+	// transaction
+	StartTx(ctx context.Context) (Tx, error)
+	ReleaseTx(tx Tx)
+
+	// account statement
+	AddDebit(ctx context.Context, tx Tx, debit *model.AccountStatement) (*model.AccountStatement, error)
+	AddReversal(ctx context.Context, tx Tx, reversal *model.AccountStatement) (*model.AccountStatement, error)
+	GetDebit(ctx context.Context, debit *model.AccountStatement) (*model.AccountStatement, error)
+	GetDebitForUpdate(ctx context.Context, tx Tx, debit *model.AccountStatement) (*model.AccountStatement, error)
+	GetAccountStatement(ctx context.Context, tx Tx, accountStatement *model.AccountStatement) (*model.AccountStatement, error)
+	GetReversedAmount(ctx context.Context, tx Tx, debit *model.AccountStatement) (decimal.Decimal, error)
+	UpdateFeeStatus(ctx context.Context, tx Tx, accountStatement *model.AccountStatement) (int64, error)
+	ListDebit(ctx context.Context, debit *model.AccountStatement, pagination *model.Pagination) (*[]model.AccountStatement, error)
+	ListDebitPerDate(ctx context.Context, debit *model.AccountStatement, dateEnd *time.Time, pagination *model.Pagination) (*[]model.AccountStatement, error)
+	ListStatementPerPeriod(ctx context.Context, fkAccountID int, dateStart time.Time, dateEnd time.Time) (*[]model.AccountStatement, error)
+	SearchStatement(ctx context.Context, filter *model.StatementFilter, pagination *model.Pagination) (*[]model.AccountStatement, error)
+
+	// account statement fee
+	AddAccountStatementFee(ctx context.Context, tx Tx, accountStatementFee model.AccountStatementFee) (*model.AccountStatementFee, error)
+	ListAccountStatementFee(ctx context.Context, tx Tx, accountStatement *model.AccountStatement) (*[]model.AccountStatementFee, error)
+	ListAccountStatementFeePerStatement(ctx context.Context, accountStatementIDs []int) (*[]model.AccountStatementFee, error)
+	ListReversedFee(ctx context.Context, tx Tx, debit *model.AccountStatement) (*[]model.AccountStatementFee, error)
+
+	// funds and limits
+	LockAccount(ctx context.Context, tx Tx, fkAccountID int) error
+	GetPendingPostingAmount(ctx context.Context, tx Tx, fkAccountID int) (decimal.Decimal, error)
+	GetPostedAmountSince(ctx context.Context, fkAccountID int, dateStart time.Time) (decimal.Decimal, error)
+	GetOverdraftLimit(ctx context.Context, tx Tx, fkAccountID int) (*model.OverdraftPolicy, error)
+	GetDebitUsage(ctx context.Context, tx Tx, fkAccountID int, debitLimit *model.DebitLimit) (*model.DebitLimit, error)
+
+	// idempotency
+	AddIdempotencyKey(ctx context.Context, tx Tx, idempotencyKey *model.IdempotencyKey) (*model.IdempotencyKey, error)
+	GetIdempotencyKey(ctx context.Context, tx Tx, idempotencyKey *model.IdempotencyKey) (*model.IdempotencyKey, error)
+	UpdateIdempotencyKey(ctx context.Context, tx Tx, idempotencyKey *model.IdempotencyKey) (int64, error)
+
+	// outbox
+	AddOutbox(ctx context.Context, tx Tx, outbox *model.Outbox) (*model.Outbox, error)
+	ListPendingOutbox(ctx context.Context, tx Tx, limit int) (*[]model.Outbox, error)
+	UpdateOutbox(ctx context.Context, tx Tx, outbox *model.Outbox) (int64, error)
+
+	// pending fee
+	AddPendingFee(ctx context.Context, tx Tx, pendingFee *model.PendingFee) (*model.PendingFee, error)
+	ListPendingFee(ctx context.Context, tx Tx, limit int) (*[]model.PendingFee, error)
+	UpdatePendingFee(ctx context.Context, tx Tx, pendingFee *model.PendingFee) (int64, error)
+	CancelPendingFee(ctx context.Context, tx Tx, accountStatement *model.AccountStatement) (int64, error)
+}
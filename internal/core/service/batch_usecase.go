@@ -0,0 +1,58 @@
+package service
+
+import(
+	"sync"
+	"context"
+	"strconv"
+
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/erro"
+)
+
+// About add a batch of debits, each one in its own transaction (a failed item does not affect the others)
+// At most BatchConfig.Concurrency debits run at the same time, the results keep the order of the items
+// When idempotencyKey is informed each item uses the key + ":" + index, so a replayed batch does not duplicate the debits
+func (s *WorkerService) AddDebitBatch(ctx context.Context, debits []model.AccountStatement, idempotencyKey string) (*[]model.BatchDebitResult, error){
+	childLogger.Info().Str("func","AddDebitBatch").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Int("items", len(debits)).Str("idempotency_key", idempotencyKey).Send()
+
+	// Trace
+	ctx, span := tracerProvider.SpanCtx(ctx, "service.AddDebitBatch")
+	defer span.End()
+
+	if len(debits) == 0 || len(debits) > s.batchConfig.MaxItems {
+		return nil, erro.ErrInvalidBatch
+	}
+
+	res_batch := make([]model.BatchDebitResult, len(debits))
+	semaphore := make(chan struct{}, max(s.batchConfig.Concurrency, 1))
+	var wg sync.WaitGroup
+
+	for i := range debits {
+		wg.Add(1)
+		semaphore <- struct{}{}
+
+		go func(index int) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			itemKey := ""
+			if idempotencyKey != "" {
+				itemKey = idempotencyKey + ":" + strconv.Itoa(index)
+			}
+
+			res_batch[index].Index = index
+			res, err := s.AddDebit(ctx, &debits[index], itemKey)
+			if err != nil {
+				res_batch[index].Status = "ERROR"
+				res_batch[index].Err = err
+				return
+			}
+			res_batch[index].Status = "OK"
+			res_batch[index].TransactionID = res.TransactionID
+			res_batch[index].Debit = res
+		}(i)
+	}
+	wg.Wait()
+
+	return &res_batch, nil
+}
@@ -0,0 +1,131 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/go-debit/internal/adapter/memory"
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/port"
+	"github.com/go-debit/internal/infra/configuration"
+)
+
+// The max connections of the go-core pool
+const poolMaxConns = 10
+
+// About the memory repository behind a pool of connections like pgxpool:
+// a transaction holds a connection until released and a read outside the transaction acquires another one
+type pooledRepository struct {
+	port.WorkerRepository
+	conns	chan struct{}
+}
+
+func newPooledRepository(maxConns int) *pooledRepository {
+	return &pooledRepository{	WorkerRepository: memory.NewMemoryRepository(),
+								conns: make(chan struct{}, maxConns) }
+}
+
+func (p *pooledRepository) acquire(ctx context.Context) error {
+	select {
+	case p.conns <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *pooledRepository) release() {
+	<-p.conns
+}
+
+func (p *pooledRepository) StartTx(ctx context.Context) (port.Tx, error) {
+	err := p.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p.WorkerRepository.StartTx(ctx)
+}
+
+func (p *pooledRepository) ReleaseTx(tx port.Tx) {
+	p.WorkerRepository.ReleaseTx(tx)
+	p.release()
+}
+
+func (p *pooledRepository) Ping(ctx context.Context) error {
+	err := p.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer p.release()
+	return p.WorkerRepository.Ping(ctx)
+}
+
+func (p *pooledRepository) GetDebit(ctx context.Context, debit *model.AccountStatement) (*model.AccountStatement, error) {
+	err := p.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer p.release()
+	return p.WorkerRepository.GetDebit(ctx, debit)
+}
+
+func (p *pooledRepository) GetPostedAmountSince(ctx context.Context, fkAccountID int, dateStart time.Time) (decimal.Decimal, error) {
+	err := p.acquire(ctx)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	defer p.release()
+	return p.WorkerRepository.GetPostedAmountSince(ctx, fkAccountID, dateStart)
+}
+
+func (p *pooledRepository) ListAccountStatementFeePerStatement(ctx context.Context, accountStatementIDs []int) (*[]model.AccountStatementFee, error) {
+	err := p.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer p.release()
+	return p.WorkerRepository.ListAccountStatementFeePerStatement(ctx, accountStatementIDs)
+}
+
+func TestAddDebitBatchDefaultConcurrency(t *testing.T) {
+	config := defaultTestConfig()
+	err := configuration.ValidateBatchConfig(&config.batch, poolMaxConns)
+	if err != nil {
+		t.Fatalf("default batch config: %v", err)
+	}
+
+	test := newTestServiceWithRepository(t, config, newPooledRepository(poolMaxConns))
+
+	debits := make([]model.AccountStatement, config.batch.Concurrency * 4)
+	for i := range debits {
+		debits[i] = *newDebit("-1.00")
+	}
+
+	ctx, cancel := context.WithTimeout(tenantContext(testTenant), 10 * time.Second)
+	defer cancel()
+
+	res, err := test.service.AddDebitBatch(ctx, debits, "batch-1")
+	if err != nil {
+		t.Fatalf("AddDebitBatch: %v", err)
+	}
+	for _, item := range *res {
+		if item.Status != "OK" {
+			t.Fatalf("item %d: status %s err %v", item.Index, item.Status, item.Err)
+		}
+		if item.TransactionID == nil || *item.TransactionID == "" {
+			t.Fatalf("item %d: without transaction_id", item.Index)
+		}
+	}
+}
+
+func TestValidateBatchConfig(t *testing.T) {
+	for _, concurrency := range []int{0, poolMaxConns, poolMaxConns + 1} {
+		batchConfig := model.BatchConfig{MaxItems: 100, Concurrency: concurrency}
+		if configuration.ValidateBatchConfig(&batchConfig, poolMaxConns) == nil {
+			t.Errorf("concurrency %d accepted with a pool of %d", concurrency, poolMaxConns)
+		}
+	}
+}
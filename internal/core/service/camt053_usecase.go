@@ -0,0 +1,190 @@
+package service
+
+import(
+	"fmt"
+	"time"
+	"context"
+	"strconv"
+	"strings"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/erro"
+)
+
+// About the credit/debit indicator of an amount
+func camt053CdtDbtInd(amount decimal.Decimal) string {
+	if amount.IsNegative() {
+		return "DBIT"
+	}
+	return "CRDT"
+}
+
+// The max length of the Max35Text elements (MsgId, Stmt Id, NtryRef, AcctSvcrRef, EndToEndId)
+const camt053MaxText = 35
+
+// About a text that fits a Max35Text, a longer one is replaced by its hash (sha256, the first 35 hex chars)
+func camt053Max35(text string) string {
+	if len(text) <= camt053MaxText {
+		return text
+	}
+	hash := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(hash[:])[:camt053MaxText]
+}
+
+// About the reference of a statement, the transaction_id without the dashes (an uuid has 36 chars, 32 without them)
+func camt053Reference(accountStatement *model.AccountStatement) string {
+	if accountStatement.TransactionID == nil {
+		return strconv.Itoa(accountStatement.ID)
+	}
+	return camt053Max35(strings.ReplaceAll(*accountStatement.TransactionID, "-", ""))
+}
+
+// About a camt.053 balance of the booking day
+func (s *WorkerService) camt053Balance(code string, amount decimal.Decimal, currency string, date time.Time) model.Camt053Bal {
+	return model.Camt053Bal{
+		Tp: model.Camt053BalTp{CdOrPrtry: model.Camt053Code{Cd: code}},
+		Amt: model.Camt053Amt{Ccy: currency, Value: amount.Abs().StringFixed(s.currencyScale(currency))},
+		CdtDbtInd: camt053CdtDbtInd(amount),
+		Dt: model.Camt053Dt{Dt: date.Format("2006-01-02")},
+	}
+}
+
+// About generate the camt.053.001.08 end-of-day statement of an account for a booking day (UTC)
+// The debits and reversals are booked entries (BOOK), the fees are not posted to the account balance so they are informative entries (INFO)
+// The opening and the closing balances are the balance in go-account less what was posted since the start and since the end of the day
+// (only the postings delivered to go-account, the entries of a posting pending or failed are not in its balance yet)
+// The summary counts all the entries (booked and informative), the net amount is only the booked entries
+func (s *WorkerService) Camt053Statement(ctx context.Context, accountID string, date time.Time) (*model.Camt053Document, error){
+	childLogger.Info().Str("func","Camt053Statement").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Str("account_id", accountID).Time("date", date).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "service.Camt053Statement")
+	defer span.End()
+
+	now := time.Now().UTC()
+	dayStart := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+	if dayStart.After(now) {
+		return nil, erro.ErrInvalidFilter
+	}
+
+	// Get the Account ID from Account-service
+	account_parsed, err := s.accountClient.GetAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	err = checkTenant(ctx, account_parsed.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	err = checkAccountOwner(ctx, account_parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the account balance from Account-service
+	accountBalance, err := s.accountClient.GetAccountBalance(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the statements and the fees of the day
+	res_statement, err := s.workerRepository.ListStatementPerPeriod(ctx, account_parsed.ID, dayStart, dayEnd)
+	if err != nil {
+		return nil, err
+	}
+	accountStatementIDs := []int{}
+	for _, accountStatement := range *res_statement {
+		accountStatementIDs = append(accountStatementIDs, accountStatement.ID)
+	}
+	fees := map[int][]model.AccountStatementFee{}
+	if len(accountStatementIDs) > 0 {
+		res_fee, err := s.workerRepository.ListAccountStatementFeePerStatement(ctx, accountStatementIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, fee := range *res_fee {
+			fees[fee.FkAccountStatementID] = append(fees[fee.FkAccountStatementID], fee)
+		}
+	}
+
+	// Balances
+	postedSinceStart, err := s.workerRepository.GetPostedAmountSince(ctx, account_parsed.ID, dayStart)
+	if err != nil {
+		return nil, err
+	}
+	postedAfter, err := s.workerRepository.GetPostedAmountSince(ctx, account_parsed.ID, dayEnd)
+	if err != nil {
+		return nil, err
+	}
+	currency := accountBalance.Currency
+	if currency == "" && len(*res_statement) > 0 {
+		currency = (*res_statement)[0].Currency
+	}
+
+	// Entries
+	booked := decimal.Zero
+	entriesSum := decimal.Zero
+	entries := []model.Camt053Ntry{}
+	for _, accountStatement := range *res_statement {
+		reference := camt053Reference(&accountStatement)
+
+		booked = booked.Add(accountStatement.Amount)
+		entriesSum = entriesSum.Add(accountStatement.Amount.Abs())
+		entries = append(entries, model.Camt053Ntry{
+			NtryRef: strconv.Itoa(accountStatement.ID),
+			Amt: model.Camt053Amt{Ccy: accountStatement.Currency, Value: accountStatement.Amount.Abs().StringFixed(s.currencyScale(accountStatement.Currency))},
+			CdtDbtInd: camt053CdtDbtInd(accountStatement.Amount),
+			RvslInd: accountStatement.Type == "REVERSAL",
+			Sts: model.Camt053Code{Cd: "BOOK"},
+			BookgDt: model.Camt053Dt{DtTm: accountStatement.ChargeAt.UTC().Format(time.RFC3339)},
+			ValDt: model.Camt053Dt{Dt: accountStatement.ChargeAt.UTC().Format("2006-01-02")},
+			AcctSvcrRef: reference,
+			BkTxCd: model.Camt053BkTxCd{Prtry: &model.Camt053Prtry{Cd: accountStatement.Type, Issr: "go-debit"}},
+			NtryDtls: &model.Camt053NtryDtls{TxDtls: []model.Camt053TxDtls{{Refs: model.Camt053Refs{AcctSvcrRef: reference, EndToEndId: reference}}}},
+		})
+
+		for _, fee := range fees[accountStatement.ID] {
+			entriesSum = entriesSum.Add(fee.Amount.Abs())
+			entries = append(entries, model.Camt053Ntry{
+				NtryRef: camt053Max35(strconv.Itoa(accountStatement.ID) + "-FEE-" + strconv.Itoa(fee.ID)),
+				Amt: model.Camt053Amt{Ccy: fee.Currency, Value: fee.Amount.Abs().StringFixed(s.currencyScale(fee.Currency))},
+				CdtDbtInd: camt053CdtDbtInd(fee.Amount),
+				Sts: model.Camt053Code{Cd: "INFO"},
+				BookgDt: model.Camt053Dt{DtTm: fee.ChargeAt.UTC().Format(time.RFC3339)},
+				ValDt: model.Camt053Dt{Dt: fee.ChargeAt.UTC().Format("2006-01-02")},
+				BkTxCd: model.Camt053BkTxCd{Domn: &model.Camt053Domn{Cd: "ACMT", Fmly: model.Camt053Fmly{Cd: "MDOP", SubFmlyCd: "CHRG"}}},
+				AddtlNtryInf: "fee " + fee.TypeFee + " of " + reference,
+			})
+		}
+	}
+
+	closing := accountBalance.Amount.Sub(postedAfter)
+	opening := accountBalance.Amount.Sub(postedSinceStart)
+
+	res_document := model.Camt053Document{Xmlns: model.Camt053Namespace}
+	res_document.BkToCstmrStmt.GrpHdr = model.Camt053GrpHdr{
+		MsgId: camt053Max35(fmt.Sprintf("CAMT053-%s-%s-%d", accountID, dayStart.Format("20060102"), now.Unix())),
+		CreDtTm: now.Format(time.RFC3339),
+	}
+	res_document.BkToCstmrStmt.Stmt = model.Camt053Stmt{
+		Id: camt053Max35(accountID + "-" + dayStart.Format("20060102")),
+		CreDtTm: now.Format(time.RFC3339),
+		FrToDt: model.Camt053FrToDt{FrDtTm: dayStart.Format(time.RFC3339), ToDtTm: dayEnd.Add(-time.Second).Format(time.RFC3339)},
+		Acct: model.Camt053Acct{Id: model.Camt053AcctId{Othr: model.Camt053Othr{Id: accountID}}, Ccy: currency},
+		Bal: []model.Camt053Bal{s.camt053Balance("OPBD", opening, currency, dayStart),
+								s.camt053Balance("CLBD", closing, currency, dayStart)},
+		TxsSummry: model.Camt053TxsSummry{TtlNtries: model.Camt053TtlNtries{
+			NbOfNtries: strconv.Itoa(len(entries)),
+			Sum: entriesSum.StringFixed(s.currencyScale(currency)),
+			TtlNetNtry: model.Camt053TtlNetNtry{Amt: booked.Abs().StringFixed(s.currencyScale(currency)), CdtDbtInd: camt053CdtDbtInd(booked)},
+		}},
+		Ntry: entries,
+	}
+
+	return &res_document, nil
+}
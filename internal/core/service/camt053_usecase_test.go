@@ -0,0 +1,122 @@
+package service_test
+
+import (
+	"context"
+	"encoding/xml"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/infra/configuration"
+)
+
+func TestCamt053Statement(t *testing.T) {
+	test := newTestService(t, defaultTestConfig())
+	ctx := tenantContext(testTenant)
+
+	res, err := test.service.AddDebit(ctx, newDebit("-100.00"), "")
+	if err != nil {
+		t.Fatalf("AddDebit: %v", err)
+	}
+	_, err = test.service.ReverseDebit(ctx, &model.AccountStatement{	AccountID: testAccount,
+																		TransactionID: res.TransactionID,
+																		Amount: decimal.RequireFromString("40.00") })
+	if err != nil {
+		t.Fatalf("ReverseDebit: %v", err)
+	}
+
+	document, err := test.service.Camt053Statement(ctx, testAccount, time.Now().UTC())
+	if err != nil {
+		t.Fatalf("Camt053Statement: %v", err)
+	}
+
+	// The debit, the reversal and the fee of each one
+	stmt := document.BkToCstmrStmt.Stmt
+	if len(stmt.Ntry) != 4 {
+		t.Fatalf("entries: %d, want 4", len(stmt.Ntry))
+	}
+	if stmt.TxsSummry.TtlNtries.NbOfNtries != strconv.Itoa(len(stmt.Ntry)) {
+		t.Errorf("NbOfNtries %s, want %d", stmt.TxsSummry.TtlNtries.NbOfNtries, len(stmt.Ntry))
+	}
+
+	max35 := []string{document.BkToCstmrStmt.GrpHdr.MsgId, stmt.Id}
+	for _, ntry := range stmt.Ntry {
+		max35 = append(max35, ntry.NtryRef, ntry.AcctSvcrRef)
+		if ntry.NtryDtls != nil {
+			for _, txDtls := range ntry.NtryDtls.TxDtls {
+				max35 = append(max35, txDtls.Refs.AcctSvcrRef, txDtls.Refs.EndToEndId)
+			}
+		}
+	}
+	for _, text := range max35 {
+		if len(text) > 35 {
+			t.Errorf("%q is longer than Max35Text", text)
+		}
+	}
+
+	// The postings are not delivered yet, the balance of go-account has none of the entries
+	balances := map[string]string{}
+	for _, bal := range stmt.Bal {
+		balances[bal.Tp.CdOrPrtry.Cd] = bal.CdtDbtInd + " " + bal.Amt.Value
+	}
+	if balances["OPBD"] != "CRDT 1000.00" || balances["CLBD"] != "CRDT 1000.00" {
+		t.Errorf("balances %v, want OPBD and CLBD CRDT 1000.00 before the postings", balances)
+	}
+
+	// Validate against the schema when xmllint is installed
+	xmllint, err := exec.LookPath("xmllint")
+	if err != nil {
+		t.Skip("xmllint not found, the schema is not validated")
+	}
+	content, err := xml.MarshalIndent(document, "", "  ")
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	file := filepath.Join(t.TempDir(), "camt053.xml")
+	err = os.WriteFile(file, append([]byte(xml.Header), content...), 0o644)
+	if err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	output, err := exec.Command(xmllint, "--noout", "--schema", filepath.Join("testdata", "camt.053.001.08.xsd"), file).CombinedOutput()
+	if err != nil {
+		t.Errorf("schema validation: %v\n%s", err, output)
+	}
+}
+
+func TestCamt053BalancesOfPostedEntries(t *testing.T) {
+	test := newTestService(t, defaultTestConfig())
+	ctx := tenantContext(testTenant)
+	outboxConfig := configuration.GetOutboxEnv()
+
+	_, err := test.service.AddDebit(ctx, newDebit("-100.00"), "")
+	if err != nil {
+		t.Fatalf("AddDebit: %v", err)
+	}
+	_, err = test.service.DispatchOutbox(context.Background(), &outboxConfig)
+	if err != nil {
+		t.Fatalf("DispatchOutbox: %v", err)
+	}
+	// The posting of the second debit is still pending
+	_, err = test.service.AddDebit(ctx, newDebit("-30.00"), "")
+	if err != nil {
+		t.Fatalf("AddDebit: %v", err)
+	}
+
+	document, err := test.service.Camt053Statement(ctx, testAccount, time.Now().UTC())
+	if err != nil {
+		t.Fatalf("Camt053Statement: %v", err)
+	}
+	balances := map[string]string{}
+	for _, bal := range document.BkToCstmrStmt.Stmt.Bal {
+		balances[bal.Tp.CdOrPrtry.Cd] = bal.Amt.Value
+	}
+	if balances["OPBD"] != "1000.00" || balances["CLBD"] != "900.00" {
+		t.Errorf("balances %v, want OPBD 1000.00 and CLBD 900.00", balances)
+	}
+}
@@ -0,0 +1,46 @@
+package service
+
+import(
+	"context"
+
+	"github.com/go-debit/internal/core/model"
+)
+
+// About list the circuit breakers of the downstream services (only for an authenticated operator)
+func (s *WorkerService) ListCircuitBreaker(ctx context.Context) (*[]model.CircuitBreakerStatus, error){
+	childLogger.Info().Str("func","ListCircuitBreaker").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "service.ListCircuitBreaker")
+	defer span.End()
+
+	err := checkAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := s.circuitBreakers.List()
+
+	return &res, nil
+}
+
+// About force the state of a circuit breaker (open, close or reset), only for an authenticated operator
+func (s *WorkerService) ChangeCircuitBreaker(ctx context.Context, name string, action string) (*model.CircuitBreakerStatus, error){
+	childLogger.Info().Str("func","ChangeCircuitBreaker").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Str("name", name).Str("action", action).Send()
+
+	// Trace
+	ctx, span := tracerProvider.SpanCtx(ctx, "service.ChangeCircuitBreaker")
+	defer span.End()
+
+	err := checkAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.circuitBreakers.Change(ctx, name, action)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,38 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/go-debit/internal/core/erro"
+)
+
+func TestCircuitBreakerOnlyForOperator(t *testing.T) {
+	test := newTestService(t, defaultTestConfig())
+
+	ctx := identityContext(testTenant, testPerson, false)
+	_, err := test.service.ListCircuitBreaker(ctx)
+	if err != erro.ErrHTTPForbiden {
+		t.Errorf("ListCircuitBreaker: %v, want %v", err, erro.ErrHTTPForbiden)
+	}
+	_, err = test.service.ChangeCircuitBreaker(ctx, "go-payfee", "open")
+	if err != erro.ErrHTTPForbiden {
+		t.Errorf("ChangeCircuitBreaker: %v, want %v", err, erro.ErrHTTPForbiden)
+	}
+
+	// Without JWT (JWT_ENABLED=false) there is no operator
+	ctx = tenantContext(testTenant)
+	_, err = test.service.ListCircuitBreaker(ctx)
+	if err != erro.ErrHTTPForbiden {
+		t.Errorf("ListCircuitBreaker without token: %v, want %v", err, erro.ErrHTTPForbiden)
+	}
+	_, err = test.service.ChangeCircuitBreaker(ctx, "go-payfee", "open")
+	if err != erro.ErrHTTPForbiden {
+		t.Errorf("ChangeCircuitBreaker without token: %v, want %v", err, erro.ErrHTTPForbiden)
+	}
+
+	ctx = identityContext(testTenant, testPerson, true)
+	_, err = test.service.ListCircuitBreaker(ctx)
+	if err != nil {
+		t.Errorf("ListCircuitBreaker operator: %v", err)
+	}
+}
@@ -6,57 +6,105 @@ import(
 	"context"
 	"net/http"
 	"encoding/json"
+	"encoding/hex"
+	"crypto/sha256"
 	"errors"
 
-	"github.com/jackc/pgx/v5"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 
-	"github.com/go-debit/internal/infra/circuitbreaker"
 	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/port"
 	"github.com/go-debit/internal/core/erro"
+	"github.com/go-debit/internal/infra/metrics"
 	go_core_observ "github.com/eliezerraj/go-core/observability"
-	go_core_api "github.com/eliezerraj/go-core/api"
 )
 
 var tracerProvider go_core_observ.TracerProvider
-var apiService go_core_api.ApiService
-
-func errorStatusCode(statusCode int) error{
-	var err error
-	switch statusCode {
-	case http.StatusUnauthorized:
-		err = erro.ErrUnauthorized
-	case http.StatusForbidden:
-		err = erro.ErrHTTPForbiden
-	case http.StatusNotFound:
-		err = erro.ErrNotFound
-	default:
-		err = erro.ErrServer
-	}
-	return err
+
+// About a new transaction id (uuid v4, like the uuid_generate_v4 of the database)
+func newTransactionID() *string {
+	transactionID := uuid.New().String()
+	return &transactionID
+}
+
+// About the fingerprint of a debit request, used to detect a reused idempotency key
+func requestFingerprint(debit *model.AccountStatement) string {
+	fingerprint, _ := json.Marshal(struct {
+		AccountID	string	`json:"account_id"`
+		Type		string	`json:"type_charge"`
+		Currency	string	`json:"currency"`
+		Amount		string	`json:"amount"`
+		TenantID	string	`json:"tenant_id"`
+	}{
+		AccountID:	debit.AccountID,
+		Type:		debit.Type,
+		Currency:	debit.Currency,
+		Amount:		debit.Amount.String(),
+		TenantID:	debit.TenantID,
+	})
+	sum := sha256.Sum256(fingerprint)
+	return hex.EncodeToString(sum[:])
+}
+
+// About replay the response stored for an idempotency key
+func (s *WorkerService) replayIdempotencyKey(ctx context.Context, tx port.Tx, idempotencyKey *model.IdempotencyKey) (*model.AccountStatement, error){
+	childLogger.Info().Str("func","replayIdempotencyKey").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Str("idempotency_key", idempotencyKey.Key).Send()
+
+	// The key is scoped by the tenant, the same key of another tenant is another entry
+	res_idempotencyKey, err := s.workerRepository.GetIdempotencyKey(ctx, tx, idempotencyKey)
+	if err == erro.ErrNotFound {
+		return nil, erro.ErrIdempotencyConflict
+	}
+	if err != nil {
+		return nil, err
+	}
+	if res_idempotencyKey.RequestHash != idempotencyKey.RequestHash {
+		return nil, erro.ErrIdempotencyConflict
+	}
+
+	var res model.AccountStatement
+	err = json.Unmarshal(res_idempotencyKey.Response, &res)
+	if err != nil {
+		childLogger.Error().Err(err).Msg("error Unmarshal")
+		return nil, erro.ErrUnmarshal
+	}
+
+	return &res, nil
 }
 
-// About add credit
-func (s *WorkerService) AddDebit(ctx context.Context, debit *model.AccountStatement) (*model.AccountStatement, error){
-	childLogger.Info().Str("func","AddDebit").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Interface("debit", debit).Send()
+// About add debit
+// When idempotencyKey is informed a retry with the same body replays the first response
+// The account balance is posted by the outbox dispatcher after the commit
+func (s *WorkerService) AddDebit(ctx context.Context, debit *model.AccountStatement, idempotencyKey string) (res_debit *model.AccountStatement, err error){
+	childLogger.Info().Str("func","AddDebit").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Interface("debit", debit).Str("idempotency_key", idempotencyKey).Send()
 
 	// Trace
 	span := tracerProvider.Span(ctx, "service.AddDebit")
 	trace_id := fmt.Sprintf("%v",ctx.Value("trace-request-id"))
 
 	// Get the database connection
-	tx, conn, err := s.workerRepository.DatabasePGServer.StartTx(ctx)
+	tx, err := s.workerRepository.StartTx(ctx)
 	if err != nil {
 		return nil, err
 	}
 	
-	// Handle the transaction
+	// Handle the transaction (the metrics of a new debit are recorded after the commit, not on a replay)
+	created := false
+	res_fees := []model.AccountStatementFee{}
 	defer func() {
 		if err != nil {
 			tx.Rollback(ctx)
 		} else {
-			tx.Commit(ctx)
+			if errCommit := tx.Commit(ctx); errCommit != nil {
+				childLogger.Error().Err(errCommit).Msg("error commit")
+				res_debit, err = nil, errors.New(errCommit.Error())
+			} else if created {
+				metrics.RecordDebit(ctx, res_debit)
+				metrics.RecordFees(ctx, res_fees)
+			}
 		}
-		s.workerRepository.DatabasePGServer.ReleaseTx(conn)
+		s.workerRepository.ReleaseTx(tx)
 		span.End()
 	}()
 
@@ -64,39 +112,99 @@ func (s *WorkerService) AddDebit(ctx context.Context, debit *model.AccountStatem
 	if debit.Type != "DEBIT" {
 		return nil, erro.ErrTransInvalid
 	}
-	if debit.Amount > 0 {
+	if debit.Amount.IsPositive() {
+		return nil, erro.ErrInvalidAmount
+	}
+	if !s.validMoneyScale(debit.Amount, debit.Currency) {
 		return nil, erro.ErrInvalidAmount
 	}
 
-	// Get the Account ID from Account-service
-	res_payload, statusCode, err := apiService.CallApi(ctx,
-														s.apiService[0].Url + "/" + debit.AccountID,
-														s.apiService[0].Method,
-														&s.apiService[0].Header_x_apigw_api_id,
-														nil,
-														&trace_id,
-														nil)
-	if err != nil {
-		return nil, errorStatusCode(statusCode)
+	// The tenant comes from the authenticated identity (another tenant in the body is rejected)
+	if debit.TenantID != "" {
+		err = checkTenant(ctx, debit.TenantID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	debit.TenantID = tenantFromContext(ctx)
+	debit.Subject = subjectFromContext(ctx)
+
+	// Reserve the idempotency key (a duplicate replays the stored response)
+	var idempotency *model.IdempotencyKey
+	if idempotencyKey != "" {
+		idempotency = &model.IdempotencyKey{Key: idempotencyKey,
+											RequestHash: requestFingerprint(debit),
+											TenantID: debit.TenantID}
+
+		_, err = s.workerRepository.AddIdempotencyKey(ctx, tx, idempotency)
+		if err == erro.ErrDuplicateKey {
+			return s.replayIdempotencyKey(ctx, tx, idempotency)
+		}
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	jsonString, err  := json.Marshal(res_payload)
+	// Get the Account ID from Account-service
+	account_parsed, err := s.accountClient.GetAccount(ctx, debit.AccountID)
 	if err != nil {
-		childLogger.Error().Err(err).Msg("error Marshal")
-		return nil, errors.New(err.Error())
-    }
-	var account_parsed model.Account
-	json.Unmarshal(jsonString, &account_parsed)
+		return nil, err
+	}
 
 	// Business rule
+	err = checkTenant(ctx, account_parsed.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	err = checkAccountOwner(ctx, account_parsed)
+	if err != nil {
+		return nil, err
+	}
 	debit.FkAccountID = account_parsed.ID
-	
-	// Get transaction UUID 
-	res_uuid, err := s.workerRepository.GetTransactionUUID(ctx)
+
+	// Concurrent debits of the same account wait here until this one commits
+	err = s.workerRepository.LockAccount(ctx, tx, debit.FkAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the daily and monthly limits
+	err = s.checkDebitLimit(ctx, tx, debit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Calc the fees before the debit (the calls to go-payfee are protected by its circuit breaker)
+	accountStatementFee := model.AccountStatementFee{}
+	accountStatementFee.Currency = debit.Currency
+	accountStatementFee.Amount	 = debit.Amount
+	accountStatementFee.TenantID = debit.TenantID
+
+	list_accountStatementFee, errFee := s.calcAccountStatementFee(ctx, accountStatementFee)
+	if (errFee != nil) {
+		childLogger.Debug().Msg("--------------------------------------------------")
+		childLogger.Error().Err(errFee).Msg(" ****** Circuit Breaker OPEN !!! ******")
+		childLogger.Debug().Msg("--------------------------------------------------")
+
+		debit.FeeStatus = "PENDING"
+	} else {
+		debit.FeeStatus = "CHARGED"
+	}
+
+	// Check the funds (balance + overdraft limit) for the debit and its fees
+	totalFee := decimal.Zero
+	if errFee == nil {
+		for _, v_accountStatementFee := range *list_accountStatementFee {
+			totalFee = totalFee.Add(v_accountStatementFee.Amount)
+		}
+	}
+	err = s.checkFunds(ctx, tx, debit, totalFee)
 	if err != nil {
 		return nil, err
 	}
-	debit.TransactionID = res_uuid
+
+	// The transaction UUID is generated here, not in the database (the tx already holds a connection of the pool)
+	debit.TransactionID = newTransactionID()
 
 	// Add the credit
 	res, err := s.workerRepository.AddDebit(ctx, tx, debit)
@@ -104,190 +212,233 @@ func (s *WorkerService) AddDebit(ctx context.Context, debit *model.AccountStatem
 		return nil, err
 	}
 
-	// Add (POST) the account statement Get the Account ID from Account-service
-	_, statusCode, err = apiService.CallApi(ctx,
-											s.apiService[1].Url,
-											s.apiService[1].Method,
-											&s.apiService[1].Header_x_apigw_api_id,
-											nil,
-											&trace_id, 
-											debit)
+	// Add the account balance posting in the outbox (same transaction)
+	outbox, err := newBalancePosting(debit, trace_id)
+	if err != nil {
+		return nil, err
+	}
+	_, err = s.workerRepository.AddOutbox(ctx, tx, outbox)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add the DebitCreated event in the outbox (same transaction)
+	err = s.addDomainEvent(ctx, tx, "DebitCreated", res, trace_id)
 	if err != nil {
-		return nil, errorStatusCode(statusCode)
-	}
-
-	//Open CB - MOCK
-	circuitBreaker := circuitbreaker.CircuitBreakerConfig()
-	_, errCB := circuitBreaker.Execute(func() (interface{}, error) {		
-		
-		// Add accountStamentFee
-		accountStatementFee := model.AccountStatementFee{}
-		accountStatementFee.FkAccountStatementID = debit.ID
-		accountStatementFee.Currency = debit.Currency
-		accountStatementFee.Amount	 = debit.Amount
-		accountStatementFee.TenantID = debit.TenantID
-
-		_, errCB := s.AddAccountStatementFee(ctx, tx , accountStatementFee)
-		if errCB != nil {
-			return nil, errCB
+		return nil, err
+	}
+
+	// Add accountStamentFee, or queue the fees to be charged later by the pending fee worker
+	if (errFee == nil) {
+		feeCharged := *res
+		for _, new_accountStatementFee := range *list_accountStatementFee {
+			new_accountStatementFee.FkAccountStatementID = res.ID
+			res_accountStatementFee, err := s.workerRepository.AddAccountStatementFee(ctx, tx, new_accountStatementFee)
+			if err != nil {
+				return nil, err
+			}
+			feeCharged.Fees = append(feeCharged.Fees, *res_accountStatementFee)
 		}
+		res_fees = feeCharged.Fees
 
-		return nil, nil
-	})
-	if (errCB != nil) {
-		childLogger.Debug().Msg("--------------------------------------------------")
-		childLogger.Error().Err(err).Msg(" ****** Circuit Breaker OPEN !!! ******")
-		childLogger.Debug().Msg("--------------------------------------------------")
+		err = s.addDomainEvent(ctx, tx, "FeeCharged", &feeCharged, trace_id)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		pendingFee := model.PendingFee{	FkAccountStatementID: res.ID,
+										Currency: debit.Currency,
+										Amount: debit.Amount,
+										TenantID: debit.TenantID,
+										TraceID: trace_id }
+
+		_, err = s.workerRepository.AddPendingFee(ctx, tx, &pendingFee)
+		if err != nil {
+			return nil, err
+		}
+
+		res.Obs =  "circuit breaker open impossible to reach the pay fees, the fees will be charged later !!!"
+	}
+
+	// Store the response for future retries
+	if idempotency != nil {
+		idempotency.StatusCode = http.StatusOK
+		idempotency.Response, err = json.Marshal(res)
+		if err != nil {
+			childLogger.Error().Err(err).Msg("error Marshal")
+			return nil, errors.New(err.Error())
+		}
+		_, err = s.workerRepository.UpdateIdempotencyKey(ctx, tx, idempotency)
+		if err != nil {
+			return nil, err
+		}
+	}
+	created = true
+
+	return res, nil
+}
+
+// About get a debit by transaction id with its fees
+func (s *WorkerService) GetDebit(ctx context.Context, debit *model.AccountStatement) (*model.AccountStatement, error){
+	childLogger.Info().Str("func","GetDebit").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Interface("debit", debit).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "service.GetDebit")
+	defer span.End()
+
+	// Business rule (the debit is not addressed by account, only for an operator)
+	debit.Type = "DEBIT"
+
+	err := checkOperator(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.workerRepository.GetDebit(ctx, debit)
+	if err != nil {
+		return nil, err
+	}
 
-		res.Obs =  "circuit breaker open impossible to reach the pay fees !!!"
+	res_fee, err := s.workerRepository.ListAccountStatementFeePerStatement(ctx, []int{res.ID})
+	if err != nil {
+		return nil, err
 	}
+	res.Fees = *res_fee
 
 	return res, nil
 }
 
-func (s *WorkerService) ListDebit(ctx context.Context, debit *model.AccountStatement) (*[]model.AccountStatement, error){
-	childLogger.Info().Str("func","ListDebit").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Interface("debit", debit).Send()
+func (s *WorkerService) ListDebit(ctx context.Context, debit *model.AccountStatement, pagination *model.Pagination) (*model.AccountStatementPage, error){
+	childLogger.Info().Str("func","ListDebit").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Interface("debit", debit).Interface("pagination", pagination).Send()
 
 	// Trace
 	span := tracerProvider.Span(ctx, "service.ListDebit")
-	trace_id := fmt.Sprintf("%v",ctx.Value("trace-request-id"))
 	defer span.End()
+
+	err := preparePagination(pagination)
+	if err != nil {
+		return nil, err
+	}
 	
 	// Get the Account ID from Account-service
-	res_payload, statusCode, err := apiService.CallApi(ctx,
-														s.apiService[0].Url + "/" + debit.AccountID,
-														s.apiService[0].Method,
-														&s.apiService[0].Header_x_apigw_api_id,
-														nil,
-														&trace_id,
-														nil)
+	account_parsed, err := s.accountClient.GetAccount(ctx, debit.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	err = checkTenant(ctx, account_parsed.TenantID)
 	if err != nil {
-		return nil, errorStatusCode(statusCode)
+		return nil, err
 	}
-
-	jsonString, err  := json.Marshal(res_payload)
+	err = checkAccountOwner(ctx, account_parsed)
 	if err != nil {
-		childLogger.Error().Err(err).Msg("error Marshal")
-		return nil, errors.New(err.Error())
-    }
-	var account_parsed model.Account
-	json.Unmarshal(jsonString, &account_parsed)
+		return nil, err
+	}
 
 	// Business rule
 	debit.FkAccountID = account_parsed.ID
 	debit.Type = "DEBIT"
 
-	res, err := s.workerRepository.ListDebit(ctx, debit)
+	res, err := s.workerRepository.ListDebit(ctx, debit, pagination)
 	if err != nil {
 		return nil, err
 	}
-	return res, nil
+	return buildAccountStatementPage(*res, pagination), nil
 }
 
-func (s *WorkerService) ListDebitPerDate(ctx context.Context, debit *model.AccountStatement) (*[]model.AccountStatement, error){
-	childLogger.Info().Str("func","ListDebitPerDate").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Interface("debit", debit).Send()
+func (s *WorkerService) ListDebitPerDate(ctx context.Context, debit *model.AccountStatement, pagination *model.Pagination) (*model.AccountStatementPage, error){
+	childLogger.Info().Str("func","ListDebitPerDate").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Interface("debit", debit).Interface("pagination", pagination).Send()
 
 	// Trace
 	span := tracerProvider.Span(ctx, "service.ListDebit'PerDate")
-	trace_id := fmt.Sprintf("%v",ctx.Value("trace-request-id"))
 	defer span.End()
+
+	err := preparePagination(pagination)
+	if err != nil {
+		return nil, err
+	}
 	
 	// Get the Account ID from Account-service
-	res_payload, statusCode, err := apiService.CallApi(ctx,
-														s.apiService[0].Url + "/" + debit.AccountID,
-														s.apiService[0].Method,
-														&s.apiService[0].Header_x_apigw_api_id,
-														nil,
-														&trace_id,
-														nil)
+	account_parsed, err := s.accountClient.GetAccount(ctx, debit.AccountID)
 	if err != nil {
-		return nil, errorStatusCode(statusCode)
+		return nil, err
 	}
-
-	jsonString, err  := json.Marshal(res_payload)
+	err = checkTenant(ctx, account_parsed.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	err = checkAccountOwner(ctx, account_parsed)
 	if err != nil {
-		childLogger.Error().Err(err).Msg("error Marshal")
-		return nil, errors.New(err.Error())
-    }
-	var account_parsed model.Account
-	json.Unmarshal(jsonString, &account_parsed)
+		return nil, err
+	}
 
 	// Business rule
 	debit.FkAccountID = account_parsed.ID
 	debit.Type = "DEBIT"
 
-	res, err := s.workerRepository.ListDebitPerDate(ctx, debit)
+	res, err := s.workerRepository.ListDebitPerDate(ctx, debit, nil, pagination)
 	if err != nil {
 		return nil, err
 	}
 
-	return res, nil
+	return buildAccountStatementPage(*res, pagination), nil
 }
 
-func (s *WorkerService) AddAccountStatementFee(ctx context.Context, tx pgx.Tx, accountStatementFee model.AccountStatementFee) (*model.AccountStatementFee, error){
-	childLogger.Info().Str("func","AddAccountStatementFee").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Interface("accountStatementFee", accountStatementFee).Send()
+// About calc the fees of a statement with the financial script of go-payfee
+// Nothing is stored, so a go-payfee failure never leaves a partial set of fees
+func (s *WorkerService) calcAccountStatementFee(ctx context.Context, accountStatementFee model.AccountStatementFee) (*[]model.AccountStatementFee, error){
+	childLogger.Info().Str("func","calcAccountStatementFee").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Interface("accountStatementFee", accountStatementFee).Send()
 
 	// Trace
-	span := tracerProvider.Span(ctx, "service.AddAccountStatementFee")
-	trace_id := fmt.Sprintf("%v",ctx.Value("trace-request-id"))
+	span := tracerProvider.Span(ctx, "service.calcAccountStatementFee")
 	defer span.End()
 
-	// Get financial script
-	script := "script.debit"
-	res_payload, statusCode, err := apiService.CallApi(ctx,
-														s.apiService[2].Url + "/" + script,
-														s.apiService[2].Method,
-														&s.apiService[2].Header_x_apigw_api_id,
-														nil,
-														&trace_id,
-														nil)
-	if err != nil {
-		return nil, errorStatusCode(statusCode)
-	}
-
-	// Unmarshall to struct
-	jsonString, err := json.Marshal(res_payload)
+	// Get financial script (cached)
+	script_parsed, err := s.getScript(ctx, "script.debit")
 	if err != nil {
-		return nil, errors.New(err.Error())
+		return nil, err
 	}
-	var script_parsed model.Script
-	json.Unmarshal(jsonString, &script_parsed)
 	
-	// Get all fees
+	// Get all fees (cached)
+	list_accountStatementFee := []model.AccountStatementFee{}
 	for _, v_fee := range script_parsed.Fee {
-		res_fee, statusCode, err := apiService.CallApi(ctx,
-														s.apiService[3].Url + "/" + v_fee,
-														s.apiService[3].Method,
-														&s.apiService[3].Header_x_apigw_api_id,
-														nil,
-														&trace_id,
-														nil)
-		if err != nil {
-			return nil, errorStatusCode(statusCode)
-		}
-
-		// Unmarshall to struct
-		jsonString, err := json.Marshal(res_fee)
+		fee_parsed, err := s.getFee(ctx, v_fee)
 		if err != nil {
-			return nil, errors.New(err.Error())
+			return nil, err
 		}
-		var fee_parsed model.Fee
-		json.Unmarshal(jsonString, &fee_parsed)
 
-		// Prepare to insert AccountStatementFee
+		// Prepare the AccountStatementFee
 		new_accountStatementFee := accountStatementFee
 		new_accountStatementFee.TypeFee = fee_parsed.Name
 		new_accountStatementFee.ValueFee = fee_parsed.Value
 		new_accountStatementFee.ChargeAt = time.Now()
-		new_accountStatementFee.Amount	= (accountStatementFee.Amount * (fee_parsed.Value/100))
+		new_accountStatementFee.Amount	= s.feeAmount(accountStatementFee.Amount, *fee_parsed, accountStatementFee.Currency)
+
+		list_accountStatementFee = append(list_accountStatementFee, new_accountStatementFee)
+	}
+
+	childLogger.Debug().Interface("script_parsed:",script_parsed).Msg("")
+
+	return &list_accountStatementFee, nil
+}
+
+func (s *WorkerService) AddAccountStatementFee(ctx context.Context, tx port.Tx, accountStatementFee model.AccountStatementFee) (*model.AccountStatementFee, error){
+	childLogger.Info().Str("func","AddAccountStatementFee").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Interface("accountStatementFee", accountStatementFee).Send()
 
+	// Trace
+	span := tracerProvider.Span(ctx, "service.AddAccountStatementFee")
+	defer span.End()
+
+	list_accountStatementFee, err := s.calcAccountStatementFee(ctx, accountStatementFee)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, new_accountStatementFee := range *list_accountStatementFee {
 		_, err = s.workerRepository.AddAccountStatementFee(ctx, tx, new_accountStatementFee)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	childLogger.Debug().Interface("script_parsed:",script_parsed).Msg("")
-
 	return &accountStatementFee, nil
 }
\ No newline at end of file
@@ -0,0 +1,185 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/go-debit/internal/core/erro"
+	"github.com/go-debit/internal/core/model"
+)
+
+// About the balance postings in the outbox (one per committed debit or reversal)
+func (test *testService) balancePostings() int {
+	count := 0
+	for _, outbox := range test.repository.ListOutbox() {
+		if outbox.EventType == "ACCOUNT_BALANCE_POSTING" {
+			count++
+		}
+	}
+	return count
+}
+
+func TestAddDebitRules(t *testing.T) {
+	test := newTestService(t, defaultTestConfig())
+	ctx := tenantContext(testTenant)
+
+	credit := newDebit("-10.00")
+	credit.Type = "CREDIT"
+	_, err := test.service.AddDebit(ctx, credit, "")
+	if err != erro.ErrTransInvalid {
+		t.Errorf("type CREDIT: %v, want %v", err, erro.ErrTransInvalid)
+	}
+
+	_, err = test.service.AddDebit(ctx, newDebit("10.00"), "")
+	if err != erro.ErrInvalidAmount {
+		t.Errorf("positive amount: %v, want %v", err, erro.ErrInvalidAmount)
+	}
+
+	_, err = test.service.AddDebit(ctx, newDebit("-10.001"), "")
+	if err != erro.ErrInvalidAmount {
+		t.Errorf("scale of BRL: %v, want %v", err, erro.ErrInvalidAmount)
+	}
+
+	if postings := test.balancePostings(); postings != 0 {
+		t.Errorf("postings: %d, want 0", postings)
+	}
+}
+
+func TestAddDebitIdempotencyReplay(t *testing.T) {
+	test := newTestService(t, defaultTestConfig())
+	ctx := tenantContext(testTenant)
+
+	first, err := test.service.AddDebit(ctx, newDebit("-10.00"), "key-1")
+	if err != nil {
+		t.Fatalf("AddDebit: %v", err)
+	}
+	replay, err := test.service.AddDebit(ctx, newDebit("-10.00"), "key-1")
+	if err != nil {
+		t.Fatalf("AddDebit replay: %v", err)
+	}
+	if *replay.TransactionID != *first.TransactionID {
+		t.Errorf("replay transaction_id %s, want %s", *replay.TransactionID, *first.TransactionID)
+	}
+
+	_, err = test.service.AddDebit(ctx, newDebit("-20.00"), "key-1")
+	if err != erro.ErrIdempotencyConflict {
+		t.Errorf("same key with another body: %v, want %v", err, erro.ErrIdempotencyConflict)
+	}
+
+	if postings := test.balancePostings(); postings != 1 {
+		t.Errorf("postings: %d, want 1", postings)
+	}
+}
+
+func TestAddDebitIdempotencyKeyPerTenant(t *testing.T) {
+	test := newTestService(t, defaultTestConfig())
+	test.account.SetAccount(	model.Account{ID: 2, AccountID: "ACC-2", PersonID: testPerson, TenantID: "TENANT-2"},
+								model.AccountBalance{AccountID: "ACC-2", Currency: "BRL", Amount: decimal.NewFromInt(1000), TenantID: "TENANT-2"})
+
+	first, err := test.service.AddDebit(tenantContext(testTenant), newDebit("-10.00"), "key-1")
+	if err != nil {
+		t.Fatalf("AddDebit: %v", err)
+	}
+
+	// The same key in another tenant is a new debit, not a conflict
+	other := newDebit("-20.00")
+	other.AccountID = "ACC-2"
+	second, err := test.service.AddDebit(tenantContext("TENANT-2"), other, "key-1")
+	if err != nil {
+		t.Fatalf("AddDebit of another tenant with the same key: %v", err)
+	}
+	if *second.TransactionID == *first.TransactionID {
+		t.Errorf("the debit of another tenant replayed transaction_id %s", *first.TransactionID)
+	}
+}
+
+func TestAddDebitInsufficientFunds(t *testing.T) {
+	test := newTestService(t, defaultTestConfig())
+	ctx := tenantContext(testTenant)
+
+	_, err := test.service.AddDebit(ctx, newDebit("-600.00"), "")
+	if err != nil {
+		t.Fatalf("AddDebit: %v", err)
+	}
+
+	// The balance is 1000 in go-account, the 600 not yet posted are counted
+	_, err = test.service.AddDebit(ctx, newDebit("-500.00"), "")
+	if err != erro.ErrInsufficientFunds {
+		t.Fatalf("over the funds: %v, want %v", err, erro.ErrInsufficientFunds)
+	}
+
+	// The overdraft limit of the account
+	test.repository.SetOverdraftLimit(testTenant, 1, decimal.NewFromInt(200))
+	_, err = test.service.AddDebit(ctx, newDebit("-500.00"), "")
+	if err != nil {
+		t.Errorf("within the overdraft limit: %v", err)
+	}
+}
+
+func TestAddDebitDailyLimit(t *testing.T) {
+	config := defaultTestConfig()
+	config.debitLimit.DailyCount = 2
+	config.debitLimit.DailyAmount = decimal.NewFromInt(100)
+	test := newTestService(t, config)
+	ctx := tenantContext(testTenant)
+
+	_, err := test.service.AddDebit(ctx, newDebit("-90.00"), "")
+	if err != nil {
+		t.Fatalf("AddDebit: %v", err)
+	}
+	_, err = test.service.AddDebit(ctx, newDebit("-20.00"), "")
+	if err != erro.ErrDebitLimitExceeded {
+		t.Errorf("over the daily amount: %v, want %v", err, erro.ErrDebitLimitExceeded)
+	}
+	_, err = test.service.AddDebit(ctx, newDebit("-5.00"), "")
+	if err != nil {
+		t.Fatalf("AddDebit: %v", err)
+	}
+	_, err = test.service.AddDebit(ctx, newDebit("-1.00"), "")
+	if err != erro.ErrDebitLimitExceeded {
+		t.Errorf("over the daily count: %v, want %v", err, erro.ErrDebitLimitExceeded)
+	}
+}
+
+func TestAddDebitFundsWithFailedPosting(t *testing.T) {
+	test := newTestService(t, defaultTestConfig())
+	ctx := tenantContext(testTenant)
+
+	// The posting of the 600 gives up (FAILED), it is still not in the balance of go-account
+	_, err := test.service.AddDebit(ctx, newDebit("-600.00"), "")
+	if err != nil {
+		t.Fatalf("AddDebit: %v", err)
+	}
+	test.account.SetError(erro.ErrServer)
+	_, err = test.service.DispatchOutbox(context.Background(), &model.OutboxConfig{BatchSize: 10, MaxAttempts: 1})
+	if err != nil {
+		t.Fatalf("DispatchOutbox: %v", err)
+	}
+	test.account.SetError(nil)
+
+	_, err = test.service.AddDebit(ctx, newDebit("-500.00"), "")
+	if err != erro.ErrInsufficientFunds {
+		t.Errorf("with a failed posting: %v, want %v", err, erro.ErrInsufficientFunds)
+	}
+
+}
+
+func TestAddDebitFundsWithFees(t *testing.T) {
+	test := newTestService(t, defaultTestConfig())
+	ctx := tenantContext(testTenant)
+
+	test.payFee.SetScript(	model.Script{Name: "script.debit", Fee: []string{"TAX"}},
+							model.Fee{Name: "TAX", Value: decimal.NewFromInt(1)})
+
+	// The balance is 1000, the fee of 1% over 995 does not fit
+	_, err := test.service.AddDebit(ctx, newDebit("-995.00"), "")
+	if err != erro.ErrInsufficientFunds {
+		t.Errorf("debit and fee over the funds: %v, want %v", err, erro.ErrInsufficientFunds)
+	}
+	_, err = test.service.AddDebit(ctx, newDebit("-990.00"), "")
+	if err != nil {
+		t.Errorf("debit and fee within the funds: %v", err)
+	}
+}
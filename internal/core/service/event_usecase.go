@@ -0,0 +1,97 @@
+package service
+
+import(
+	"time"
+	"context"
+	"strconv"
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/port"
+	"github.com/go-debit/internal/core/erro"
+)
+
+// About the publisher of the domain events (kafka or in memory)
+type EventPublisher interface {
+	Publish(ctx context.Context, key string, domainEvent *model.DomainEvent) error
+}
+
+// About the CloudEvents type of each domain event (outbox event_type)
+var domainEventType = map[string]string{
+	"DebitCreated":		"com.go-debit.debit.created.v1",
+	"FeeCharged":		"com.go-debit.fee.charged.v1",
+	"DebitReversed":	"com.go-debit.debit.reversed.v1",
+}
+
+// About create the outbox message of a domain event, nothing is created when the events are disabled
+// The event is published by the outbox dispatcher only after the commit of the account statement
+func (s *WorkerService) newDomainEvent(eventType string, accountStatement *model.AccountStatement, trace_id string) (*model.Outbox, error) {
+	if s.eventPublisher == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(accountStatement)
+	if err != nil {
+		return nil, errors.New(err.Error())
+	}
+
+	domainEvent := model.DomainEvent{	SpecVersion: "1.0",
+										ID: uuid.New().String(),
+										Source: s.eventConfig.Source,
+										Type: domainEventType[eventType],
+										Subject: strconv.Itoa(accountStatement.FkAccountID),
+										Time: time.Now(),
+										DataContentType: "application/json",
+										TenantID: accountStatement.TenantID,
+										TraceID: trace_id,
+										Data: data,
+	}
+	if accountStatement.TransactionID != nil {
+		domainEvent.TransactionID = *accountStatement.TransactionID
+	}
+
+	payload, err := json.Marshal(domainEvent)
+	if err != nil {
+		return nil, errors.New(err.Error())
+	}
+
+	return &model.Outbox{	FkAccountStatementID: accountStatement.ID,
+							TransactionID: accountStatement.TransactionID,
+							EventType: eventType,
+							Payload: payload,
+							TraceID: trace_id,
+	}, nil
+}
+
+// About add the outbox message of a domain event (same transaction of the account statement)
+func (s *WorkerService) addDomainEvent(ctx context.Context, tx port.Tx, eventType string, accountStatement *model.AccountStatement, trace_id string) error {
+	outbox, err := s.newDomainEvent(eventType, accountStatement, trace_id)
+	if err != nil || outbox == nil {
+		return err
+	}
+
+	_, err = s.workerRepository.AddOutbox(ctx, tx, outbox)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// About publish the domain event of an outbox message, the key (account) keeps the order of the events of an account
+func (s *WorkerService) publishDomainEvent(ctx context.Context, outbox *model.Outbox) error {
+	if s.eventPublisher == nil {
+		return erro.ErrEventPublisher
+	}
+
+	var domainEvent model.DomainEvent
+	err := json.Unmarshal(outbox.Payload, &domainEvent)
+	if err != nil {
+		return errors.New(err.Error())
+	}
+
+	return s.eventPublisher.Publish(ctx, domainEvent.Subject, &domainEvent)
+}
@@ -0,0 +1,104 @@
+package service
+
+import(
+	"context"
+
+	"github.com/go-debit/internal/core/model"
+)
+
+// About the rows read per page of the export
+const exportPageSize = 500
+
+// About read the debits and the reversals (and their fees) of the account in the period, page by page, and hand each one to write
+// The account, its balance and its currency are resolved before the first write, so the caller can still answer an error
+func (s *WorkerService) ExportStatement(ctx context.Context, 
+										statementExport *model.StatementExport, 
+										write func(*model.AccountStatement, []model.AccountStatementFee) error) error{
+	childLogger.Info().Str("func","ExportStatement").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Interface("statementExport", statementExport).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "service.ExportStatement")
+	defer span.End()
+
+	// Get the Account ID from Account-service
+	account_parsed, err := s.accountClient.GetAccount(ctx, statementExport.AccountID)
+	if err != nil {
+		return err
+	}
+	err = checkTenant(ctx, account_parsed.TenantID)
+	if err != nil {
+		return err
+	}
+	err = checkAccountOwner(ctx, account_parsed)
+	if err != nil {
+		return err
+	}
+
+	// Get the account balance from Account-service (the ledger balance of the statement)
+	accountBalance, err := s.accountClient.GetAccountBalance(ctx, statementExport.AccountID)
+	if err != nil {
+		return err
+	}
+	statementExport.Balance = accountBalance
+
+	// The currency of the statement is the one of the balance, or of the last statement when go-account has none (a period without rows still has one)
+	statementExport.Currency = accountBalance.Currency
+	if statementExport.Currency == "" {
+		res_last, err := s.workerRepository.ListDebitPerDate(ctx, &model.AccountStatement{FkAccountID: account_parsed.ID}, nil, &model.Pagination{Limit: 1})
+		if err != nil {
+			return err
+		}
+		if len(*res_last) > 0 {
+			statementExport.Currency = (*res_last)[0].Currency
+		}
+	}
+
+	// Business rule (the empty type lists the debits and the reversals)
+	debit := model.AccountStatement{FkAccountID: account_parsed.ID,
+									AccountID: statementExport.AccountID,
+									ChargeAt: statementExport.DateStart}
+	pagination := model.Pagination{Limit: exportPageSize}
+
+	for {
+		res, err := s.workerRepository.ListDebitPerDate(ctx, &debit, &statementExport.DateEnd, &pagination)
+		if err != nil {
+			return err
+		}
+		list_accountStatement := *res
+		if len(list_accountStatement) > pagination.Limit {
+			list_accountStatement = list_accountStatement[:pagination.Limit]
+		}
+		if len(list_accountStatement) == 0 {
+			return nil
+		}
+
+		// Get the fees of the page
+		accountStatementIDs := []int{}
+		for _, accountStatement := range list_accountStatement {
+			accountStatementIDs = append(accountStatementIDs, accountStatement.ID)
+		}
+		res_fee, err := s.workerRepository.ListAccountStatementFeePerStatement(ctx, accountStatementIDs)
+		if err != nil {
+			return err
+		}
+		fees := map[int][]model.AccountStatementFee{}
+		for _, fee := range *res_fee {
+			fees[fee.FkAccountStatementID] = append(fees[fee.FkAccountStatementID], fee)
+		}
+
+		for i := range list_accountStatement {
+			list_accountStatement[i].AccountID = statementExport.AccountID
+			err = write(&list_accountStatement[i], fees[list_accountStatement[i].ID])
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(*res) <= pagination.Limit {
+			return nil
+		}
+		last := list_accountStatement[len(list_accountStatement) - 1]
+		pagination.ChargeAt = &last.ChargeAt
+		pagination.ID = last.ID
+	}
+}
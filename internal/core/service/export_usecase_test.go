@@ -0,0 +1,83 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/go-debit/internal/core/model"
+)
+
+func TestExportStatementWithReversals(t *testing.T) {
+	test := newTestService(t, defaultTestConfig())
+	ctx := tenantContext(testTenant)
+
+	res, err := test.service.AddDebit(ctx, newDebit("-100.00"), "")
+	if err != nil {
+		t.Fatalf("AddDebit: %v", err)
+	}
+	_, err = test.service.ReverseDebit(ctx, &model.AccountStatement{	AccountID: testAccount,
+																		TransactionID: res.TransactionID,
+																		Amount: decimal.RequireFromString("40.00") })
+	if err != nil {
+		t.Fatalf("ReverseDebit: %v", err)
+	}
+
+	now := time.Now().UTC()
+	statementExport := model.StatementExport{	AccountID: testAccount,
+												DateStart: now.AddDate(0, 0, -1),
+												DateEnd: now.AddDate(0, 0, 1) }
+	types := map[string]int{}
+	fees := map[string]int{}
+	err = test.service.ExportStatement(ctx, &statementExport, func(accountStatement *model.AccountStatement, accountStatementFees []model.AccountStatementFee) error {
+		types[accountStatement.Type]++
+		fees[accountStatement.Type] += len(accountStatementFees)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ExportStatement: %v", err)
+	}
+
+	if types["DEBIT"] != 1 || types["REVERSAL"] != 1 {
+		t.Errorf("rows %v, want one DEBIT and one REVERSAL", types)
+	}
+	if fees["DEBIT"] != 1 || fees["REVERSAL"] != 1 {
+		t.Errorf("fees %v, want the fee of the debit and its reversal", fees)
+	}
+	if statementExport.Currency != "BRL" {
+		t.Errorf("currency %q, want BRL", statementExport.Currency)
+	}
+}
+
+func TestExportStatementCurrencyWithoutRows(t *testing.T) {
+	test := newTestService(t, defaultTestConfig())
+	ctx := tenantContext(testTenant)
+
+	_, err := test.service.AddDebit(ctx, newDebit("-10.00"), "")
+	if err != nil {
+		t.Fatalf("AddDebit: %v", err)
+	}
+
+	// go-account without the currency of the balance and a period without rows
+	test.account.SetAccount(	model.Account{ID: 1, AccountID: testAccount, PersonID: testPerson, TenantID: testTenant},
+								model.AccountBalance{AccountID: testAccount, Amount: decimal.NewFromInt(990), TenantID: testTenant})
+
+	statementExport := model.StatementExport{	AccountID: testAccount,
+												DateStart: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+												DateEnd: time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC) }
+	rows := 0
+	err = test.service.ExportStatement(ctx, &statementExport, func(accountStatement *model.AccountStatement, accountStatementFees []model.AccountStatementFee) error {
+		rows++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ExportStatement: %v", err)
+	}
+	if rows != 0 {
+		t.Errorf("rows: %d, want 0", rows)
+	}
+	if statementExport.Currency != "BRL" {
+		t.Errorf("currency %q, want BRL (the last statement of the account)", statementExport.Currency)
+	}
+}
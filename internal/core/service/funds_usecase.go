@@ -0,0 +1,63 @@
+package service
+
+import(
+	"context"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/port"
+	"github.com/go-debit/internal/core/erro"
+)
+
+// About the overdraft policy of a tenant (the default when the tenant has none)
+func (s *WorkerService) overdraftPolicy(tenantID string) model.OverdraftPolicy {
+	if policy, ok := s.overdraftConfig.Tenant[tenantID]; ok {
+		return policy
+	}
+	return s.overdraftConfig.Default
+}
+
+// About check the account has funds for the debit and its fees (negative, zero when the fees are pending)
+// available = balance in go-account + statements not yet delivered to go-account (outbox pending or failed) + overdraft limit (of the account or of the tenant)
+func (s *WorkerService) checkFunds(ctx context.Context, tx port.Tx, debit *model.AccountStatement, fee decimal.Decimal) error{
+	childLogger.Info().Str("func","checkFunds").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "service.checkFunds")
+	defer span.End()
+
+	policy := s.overdraftPolicy(debit.TenantID)
+	if !policy.CheckFunds {
+		return nil
+	}
+
+	// Get the account balance from Account-service
+	accountBalance, err := s.accountClient.GetAccountBalance(ctx, debit.AccountID)
+	if err != nil {
+		return err
+	}
+
+	// Statements already committed but not yet posted to the balance (a FAILED posting is not in the balance either)
+	pending, err := s.workerRepository.GetPendingPostingAmount(ctx, tx, debit.FkAccountID)
+	if err != nil {
+		return err
+	}
+
+	// The limit of the account overrides the limit of the tenant
+	accountPolicy, err := s.workerRepository.GetOverdraftLimit(ctx, tx, debit.FkAccountID)
+	if err != nil && err != erro.ErrNotFound {
+		return err
+	}
+	if accountPolicy != nil {
+		policy = *accountPolicy
+	}
+
+	available := accountBalance.Amount.Add(pending).Add(policy.Limit)
+	if available.Add(debit.Amount).Add(fee).IsNegative() {
+		childLogger.Error().Str("account_id", debit.AccountID).Str("available", available.String()).Str("amount", debit.Amount.String()).Str("fee", fee.String()).Msg("insufficient funds")
+		return erro.ErrInsufficientFunds
+	}
+
+	return nil
+}
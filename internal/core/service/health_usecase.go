@@ -0,0 +1,78 @@
+package service
+
+import(
+	"sync"
+	"time"
+	"context"
+	"slices"
+
+	"github.com/go-debit/internal/core/model"
+)
+
+// About check a dependency with the timeout of the readiness
+func (s *WorkerService) checkDependency(ctx context.Context, name string, ping func(context.Context) error) model.DependencyStatus {
+	ctxPing, cancel := context.WithTimeout(ctx, time.Duration(s.healthConfig.Timeout) * time.Millisecond)
+	defer cancel()
+
+	dependencyStatus := model.DependencyStatus{	Name: name,
+												Status: "UP",
+												Critical: slices.Contains(s.healthConfig.Critical, name) }
+
+	start := time.Now()
+	err := ping(ctxPing)
+	dependencyStatus.Latency = time.Since(start).Milliseconds()
+	if err != nil {
+		childLogger.Error().Err(err).Str("dependency", name).Msg("dependency down")
+		dependencyStatus.Status = "DOWN"
+		dependencyStatus.Error = err.Error()
+	}
+
+	return dependencyStatus
+}
+
+// About the readiness of the service, the dependencies are checked in parallel
+// The database is always checked, go-account and go-payfee only when their health url is set
+// The status is DOWN when a critical dependency (HEALTH_CRITICAL) is down
+func (s *WorkerService) Health(ctx context.Context) *model.HealthStatus {
+	childLogger.Info().Str("func","Health").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Send()
+
+	checks := map[string]func(context.Context) error{ "database": s.workerRepository.Ping }
+	if s.healthConfig.AccountUrl != "" {
+		checks["go-account"] = s.accountClient.Ping
+	}
+	if s.healthConfig.PayFeeUrl != "" {
+		checks["go-payfee"] = s.payFeeClient.Ping
+	}
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	healthStatus := model.HealthStatus{Status: "UP", Dependencies: []model.DependencyStatus{}}
+
+	for name, ping := range checks {
+		wg.Add(1)
+		go func(name string, ping func(context.Context) error) {
+			defer wg.Done()
+			dependencyStatus := s.checkDependency(ctx, name, ping)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			healthStatus.Dependencies = append(healthStatus.Dependencies, dependencyStatus)
+			if dependencyStatus.Critical && dependencyStatus.Status == "DOWN" {
+				healthStatus.Status = "DOWN"
+			}
+		}(name, ping)
+	}
+	wg.Wait()
+
+	slices.SortFunc(healthStatus.Dependencies, func(a, b model.DependencyStatus) int {
+		if a.Name < b.Name {
+			return -1
+		}
+		if a.Name > b.Name {
+			return 1
+		}
+		return 0
+	})
+
+	return &healthStatus
+}
@@ -0,0 +1,142 @@
+package service
+
+import(
+	"time"
+	"context"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/port"
+	"github.com/go-debit/internal/core/erro"
+)
+
+// About the limits and the periods (day and month in UTC) that contain the time
+func (s *WorkerService) newDebitLimit(accountID string, now time.Time) *model.DebitLimit {
+	now = now.UTC()
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	debitLimit := model.DebitLimit{AccountID: accountID}
+	debitLimit.Daily.PeriodStart = dayStart
+	debitLimit.Daily.PeriodEnd = dayStart.AddDate(0, 0, 1)
+	debitLimit.Monthly.PeriodStart = monthStart
+	debitLimit.Monthly.PeriodEnd = monthStart.AddDate(0, 1, 0)
+
+	if s.debitLimitConfig.DailyAmount.IsPositive() {
+		debitLimit.Daily.MaxAmount = &s.debitLimitConfig.DailyAmount
+	}
+	if s.debitLimitConfig.DailyCount > 0 {
+		debitLimit.Daily.MaxCount = &s.debitLimitConfig.DailyCount
+	}
+	if s.debitLimitConfig.MonthlyAmount.IsPositive() {
+		debitLimit.Monthly.MaxAmount = &s.debitLimitConfig.MonthlyAmount
+	}
+	if s.debitLimitConfig.MonthlyCount > 0 {
+		debitLimit.Monthly.MaxCount = &s.debitLimitConfig.MonthlyCount
+	}
+
+	return &debitLimit
+}
+
+// About the headroom of a period (never negative), nil when there is no limit
+func remainingDebitLimit(usage *model.DebitLimitUsage) {
+	if usage.MaxAmount != nil {
+		remaining := decimal.Max(usage.MaxAmount.Sub(usage.UsedAmount), decimal.Zero)
+		usage.RemainingAmount = &remaining
+	}
+	if usage.MaxCount != nil {
+		remaining := max(*usage.MaxCount - usage.UsedCount, 0)
+		usage.RemainingCount = &remaining
+	}
+}
+
+// About check the debit fits in the headroom of the period
+func exceedDebitLimit(usage *model.DebitLimitUsage, amount decimal.Decimal) bool {
+	if usage.MaxAmount != nil && usage.UsedAmount.Add(amount).GreaterThan(*usage.MaxAmount) {
+		return true
+	}
+	if usage.MaxCount != nil && usage.UsedCount + 1 > *usage.MaxCount {
+		return true
+	}
+	return false
+}
+
+// About check the daily and monthly limits of the account (the caller holds the lock of the account)
+func (s *WorkerService) checkDebitLimit(ctx context.Context, tx port.Tx, debit *model.AccountStatement) error{
+	childLogger.Info().Str("func","checkDebitLimit").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "service.checkDebitLimit")
+	defer span.End()
+
+	debitLimit := s.newDebitLimit(debit.AccountID, time.Now())
+	if debitLimit.Daily.MaxAmount == nil && debitLimit.Daily.MaxCount == nil &&
+		debitLimit.Monthly.MaxAmount == nil && debitLimit.Monthly.MaxCount == nil {
+		return nil
+	}
+
+	debitLimit, err := s.workerRepository.GetDebitUsage(ctx, tx, debit.FkAccountID, debitLimit)
+	if err != nil {
+		return err
+	}
+
+	amount := debit.Amount.Abs()
+	if exceedDebitLimit(&debitLimit.Daily, amount) || exceedDebitLimit(&debitLimit.Monthly, amount) {
+		childLogger.Error().Str("account_id", debit.AccountID).Interface("debitLimit", debitLimit).Str("amount", amount.String()).Msg("debit limit exceeded")
+		return erro.ErrDebitLimitExceeded
+	}
+
+	return nil
+}
+
+// About get the limits of the account and the headroom of the current day and month
+func (s *WorkerService) GetDebitLimit(ctx context.Context, accountID string) (res_debitLimit *model.DebitLimit, err error){
+	childLogger.Info().Str("func","GetDebitLimit").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Str("account_id", accountID).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "service.GetDebitLimit")
+
+	// Get the database connection
+	tx, err := s.workerRepository.StartTx(ctx)
+	if err != nil {
+		span.End()
+		return nil, err
+	}
+	
+	// Handle the transaction
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			tx.Commit(ctx)
+		}
+		s.workerRepository.ReleaseTx(tx)
+		span.End()
+	}()
+
+	// Get the Account ID from Account-service
+	account_parsed, err := s.accountClient.GetAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	err = checkTenant(ctx, account_parsed.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	err = checkAccountOwner(ctx, account_parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	debitLimit := s.newDebitLimit(accountID, time.Now())
+	debitLimit, err = s.workerRepository.GetDebitUsage(ctx, tx, account_parsed.ID, debitLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	remainingDebitLimit(&debitLimit.Daily)
+	remainingDebitLimit(&debitLimit.Monthly)
+
+	return debitLimit, nil
+}
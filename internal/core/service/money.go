@@ -0,0 +1,55 @@
+package service
+
+import(
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"github.com/go-debit/internal/core/model"
+)
+
+var decimalHundred = decimal.NewFromInt(100)
+
+// About the minor unit scale of a currency (2 when not configured)
+func (s *WorkerService) currencyScale(currency string) int32 {
+	if s.moneyConfig != nil {
+		if scale, ok := s.moneyConfig.CurrencyScale[strings.ToUpper(currency)]; ok {
+			return scale
+		}
+	}
+	return 2
+}
+
+// About round an amount to the minor unit of the currency using the configured rounding mode
+func (s *WorkerService) roundMoney(amount decimal.Decimal, currency string) decimal.Decimal {
+	scale := s.currencyScale(currency)
+
+	roundingMode := ""
+	if s.moneyConfig != nil {
+		roundingMode = s.moneyConfig.RoundingMode
+	}
+
+	switch roundingMode {
+	case "HALF_EVEN":
+		return amount.RoundBank(scale)
+	case "DOWN":
+		return amount.RoundDown(scale)
+	case "UP":
+		return amount.RoundUp(scale)
+	case "FLOOR":
+		return amount.RoundFloor(scale)
+	case "CEILING":
+		return amount.RoundCeil(scale)
+	default:
+		return amount.Round(scale) // HALF_UP
+	}
+}
+
+// About check if the amount fits the minor unit of the currency
+func (s *WorkerService) validMoneyScale(amount decimal.Decimal, currency string) bool {
+	return amount.Equal(amount.Truncate(s.currencyScale(currency)))
+}
+
+// About calc a fee (percentage) over an amount
+func (s *WorkerService) feeAmount(amount decimal.Decimal, fee model.Fee, currency string) decimal.Decimal {
+	return s.roundMoney(amount.Mul(fee.Value).Div(decimalHundred), currency)
+}
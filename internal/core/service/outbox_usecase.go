@@ -0,0 +1,168 @@
+package service
+
+import(
+	"time"
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/go-debit/internal/core/model"
+)
+
+// About create the outbox message that posts the statement amount to the account balance
+func newBalancePosting(accountStatement *model.AccountStatement, trace_id string) (*model.Outbox, error) {
+	payload, err := json.Marshal(accountStatement)
+	if err != nil {
+		return nil, errors.New(err.Error())
+	}
+
+	return &model.Outbox{	FkAccountStatementID: accountStatement.ID,
+							TransactionID: accountStatement.TransactionID,
+							EventType: "ACCOUNT_BALANCE_POSTING",
+							Payload: payload,
+							TraceID: trace_id,
+	}, nil
+}
+
+// About the idempotency key of a balance posting, the transaction_id of the statement (the id of the message when missing)
+func postingIdempotencyKey(outbox *model.Outbox) string {
+	if outbox.TransactionID != nil && *outbox.TransactionID != "" {
+		return *outbox.TransactionID
+	}
+	return "outbox-" + strconv.Itoa(outbox.ID)
+}
+
+// The size of the column last_error
+const lastErrorSize = 255
+
+// About the error saved in last_error, truncated to the size of the column (an update that fails would roll back the delivery)
+func lastError(err error) *string {
+	last_error := []rune(err.Error())
+	if len(last_error) > lastErrorSize {
+		last_error = last_error[:lastErrorSize]
+	}
+	res_last_error := string(last_error)
+	return &res_last_error
+}
+
+// About the wait before the next attempt (exponential, max 5 minutes)
+func retryBackoff(attempts int) time.Duration {
+	backoff := time.Duration(1 << min(attempts, 8)) * time.Second
+	return min(backoff, 5 * time.Minute)
+}
+
+// About deliver the pending messages of the outbox until the ctx is done
+// The balance postings go to the Account-service and the domain events to the event publisher
+func (s *WorkerService) OutboxDispatcher(ctx context.Context, outboxConfig *model.OutboxConfig) {
+	childLogger.Info().Str("func","OutboxDispatcher").Interface("outboxConfig", outboxConfig).Send()
+
+	ticker := time.NewTicker(time.Duration(max(outboxConfig.PollInterval, 1)) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			childLogger.Info().Msg("stop outbox dispatcher !!!")
+			return
+		case <-ticker.C:
+			count, err := s.DispatchOutbox(ctx, outboxConfig)
+			if err != nil {
+				childLogger.Error().Err(err).Msg("error dispatch outbox")
+			}
+			if count > 0 {
+				childLogger.Info().Int("delivered", count).Msg("outbox dispatched")
+			}
+		}
+	}
+}
+
+// About deliver one batch of pending messages, returns how many were delivered
+// Each message is claimed, delivered and updated in its own transaction, so the status is committed right after
+// the call and an error in the next message does not roll back (and deliver again) the messages already delivered
+func (s *WorkerService) DispatchOutbox(ctx context.Context, outboxConfig *model.OutboxConfig) (count int, err error){
+	childLogger.Debug().Str("func","DispatchOutbox").Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "service.DispatchOutbox")
+	defer span.End()
+
+	for i := 0; i < outboxConfig.BatchSize; i++ {
+		found, delivered, err := s.dispatchOutboxMessage(ctx, outboxConfig)
+		if err != nil {
+			return count, err
+		}
+		if !found {
+			break
+		}
+		if delivered {
+			count = count + 1
+		}
+	}
+
+	return count, nil
+}
+
+// About claim the next pending message (locked until the commit), deliver it and update its status
+func (s *WorkerService) dispatchOutboxMessage(ctx context.Context, outboxConfig *model.OutboxConfig) (found bool, delivered bool, err error){
+	// Get the database connection
+	tx, err := s.workerRepository.StartTx(ctx)
+	if err != nil {
+		return false, false, err
+	}
+
+	// Handle the transaction
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+		s.workerRepository.ReleaseTx(tx)
+	}()
+
+	list_outbox, err := s.workerRepository.ListPendingOutbox(ctx, tx, 1)
+	if err != nil {
+		return false, false, err
+	}
+	if len(*list_outbox) == 0 {
+		return false, false, nil
+	}
+	outbox := (*list_outbox)[0]
+
+	var errCall error
+	if outbox.EventType == "ACCOUNT_BALANCE_POSTING" {
+		// Add (POST) the account statement to the Account-service (with the trace id of the debit)
+		var accountStatement model.AccountStatement
+		errCall = json.Unmarshal(outbox.Payload, &accountStatement)
+		if errCall == nil {
+			errCall = s.accountClient.PostBalance(context.WithValue(ctx, "trace-request-id", outbox.TraceID), &accountStatement, postingIdempotencyKey(&outbox))
+		}
+	} else {
+		// Publish the domain event (DebitCreated, FeeCharged, DebitReversed)
+		errCall = s.publishDomainEvent(ctx, &outbox)
+	}
+
+	outbox.Attempts = outbox.Attempts + 1
+	if errCall == nil {
+		delivered_at := time.Now()
+		outbox.Status = "DELIVERED"
+		outbox.DeliveredAt = &delivered_at
+		outbox.LastError = nil
+	} else {
+		childLogger.Error().Err(errCall).Int("outbox_id", outbox.ID).Int("attempts", outbox.Attempts).Msg("error deliver outbox")
+
+		outbox.LastError = lastError(errCall)
+		outbox.NextAttemptAt = time.Now().Add(retryBackoff(outbox.Attempts))
+		if outbox.Attempts >= outboxConfig.MaxAttempts {
+			outbox.Status = "FAILED"
+		}
+	}
+
+	_, err = s.workerRepository.UpdateOutbox(ctx, tx, &outbox)
+	if err != nil {
+		return true, false, err
+	}
+
+	return true, errCall == nil, nil
+}
@@ -0,0 +1,73 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-debit/internal/infra/configuration"
+)
+
+func TestDispatchOutboxDeliversOnce(t *testing.T) {
+	test := newTestService(t, defaultTestConfig())
+	ctx := tenantContext(testTenant)
+	outboxConfig := configuration.GetOutboxEnv()
+
+	res, err := test.service.AddDebit(ctx, newDebit("-10.00"), "")
+	if err != nil {
+		t.Fatalf("AddDebit: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err = test.service.DispatchOutbox(context.Background(), &outboxConfig)
+		if err != nil {
+			t.Fatalf("DispatchOutbox: %v", err)
+		}
+	}
+
+	postings := test.account.Postings()
+	if len(postings) != 1 {
+		t.Fatalf("postings: %d, want 1", len(postings))
+	}
+	if *postings[0].TransactionID != *res.TransactionID {
+		t.Errorf("posting transaction_id %s, want %s", *postings[0].TransactionID, *res.TransactionID)
+	}
+	for _, outbox := range test.repository.ListOutbox() {
+		if outbox.EventType == "ACCOUNT_BALANCE_POSTING" && outbox.Status != "DELIVERED" {
+			t.Errorf("outbox %d: status %s, want DELIVERED", outbox.ID, outbox.Status)
+		}
+	}
+}
+
+func TestDispatchOutboxTruncatesLastError(t *testing.T) {
+	test := newTestService(t, defaultTestConfig())
+	ctx := tenantContext(testTenant)
+	outboxConfig := configuration.GetOutboxEnv()
+
+	_, err := test.service.AddDebit(ctx, newDebit("-10.00"), "")
+	if err != nil {
+		t.Fatalf("AddDebit: %v", err)
+	}
+
+	test.account.SetError(errors.New(strings.Repeat("x", 1000)))
+	count, err := test.service.DispatchOutbox(context.Background(), &outboxConfig)
+	if err != nil {
+		t.Fatalf("DispatchOutbox: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("delivered: %d, want 0", count)
+	}
+
+	for _, outbox := range test.repository.ListOutbox() {
+		if outbox.EventType != "ACCOUNT_BALANCE_POSTING" {
+			continue
+		}
+		if outbox.Status != "PENDING" || outbox.Attempts != 1 {
+			t.Errorf("outbox %d: status %s attempts %d, want PENDING 1", outbox.ID, outbox.Status, outbox.Attempts)
+		}
+		if outbox.LastError == nil || len(*outbox.LastError) != 255 {
+			t.Errorf("outbox %d: last_error not truncated to 255", outbox.ID)
+		}
+	}
+}
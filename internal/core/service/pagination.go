@@ -0,0 +1,109 @@
+package service
+
+import(
+	"fmt"
+	"time"
+	"strings"
+	"strconv"
+	"encoding/base64"
+
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/erro"
+)
+
+const (
+	defaultPageLimit = 50
+	maxPageLimit = 500
+)
+
+// About prepare the pagination, setting the default limit and decoding the cursor keyset
+func preparePagination(pagination *model.Pagination) error {
+	if pagination.Limit < 0 || pagination.Limit > maxPageLimit {
+		return erro.ErrInvalidLimit
+	}
+	if pagination.Limit == 0 {
+		pagination.Limit = defaultPageLimit
+	}
+
+	if pagination.Cursor == "" {
+		return nil
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(pagination.Cursor)
+	if err != nil {
+		return erro.ErrInvalidCursor
+	}
+	keyset := strings.SplitN(string(decoded), "|", 2)
+	if len(keyset) != 2 {
+		return erro.ErrInvalidCursor
+	}
+	chargeAt, err := time.Parse(time.RFC3339Nano, keyset[0])
+	if err != nil {
+		return erro.ErrInvalidCursor
+	}
+	id, err := strconv.Atoi(keyset[1])
+	if err != nil {
+		return erro.ErrInvalidCursor
+	}
+
+	pagination.ChargeAt = &chargeAt
+	pagination.ID = id
+
+	return nil
+}
+
+// About build the page, the repository reads one extra row when there is a next page
+func buildAccountStatementPage(list []model.AccountStatement, pagination *model.Pagination) *model.AccountStatementPage {
+	page := model.AccountStatementPage{Data: list}
+
+	if len(list) > pagination.Limit {
+		page.Data = list[:pagination.Limit]
+		last := page.Data[len(page.Data)-1]
+		keyset := fmt.Sprintf("%s|%d", last.ChargeAt.UTC().Format(time.RFC3339Nano), last.ID)
+		page.NextCursor = base64.RawURLEncoding.EncodeToString([]byte(keyset))
+	}
+
+	return &page
+}
+
+// About prepare a pagination where the cursor carries an offset (used by the search, sorted by any column)
+func prepareOffsetPagination(pagination *model.Pagination) (int, error) {
+	if pagination.Limit < 0 || pagination.Limit > maxPageLimit {
+		return 0, erro.ErrInvalidLimit
+	}
+	if pagination.Limit == 0 {
+		pagination.Limit = defaultPageLimit
+	}
+
+	if pagination.Cursor == "" {
+		return 0, nil
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(pagination.Cursor)
+	if err != nil {
+		return 0, erro.ErrInvalidCursor
+	}
+	offset, found := strings.CutPrefix(string(decoded), "offset|")
+	if !found {
+		return 0, erro.ErrInvalidCursor
+	}
+	res_offset, err := strconv.Atoi(offset)
+	if err != nil || res_offset < 0 {
+		return 0, erro.ErrInvalidCursor
+	}
+
+	return res_offset, nil
+}
+
+// About build the page of an offset pagination
+func buildOffsetPage(list []model.AccountStatement, pagination *model.Pagination, offset int) *model.AccountStatementPage {
+	page := model.AccountStatementPage{Data: list}
+
+	if len(list) > pagination.Limit {
+		page.Data = list[:pagination.Limit]
+		keyset := fmt.Sprintf("offset|%d", offset + pagination.Limit)
+		page.NextCursor = base64.RawURLEncoding.EncodeToString([]byte(keyset))
+	}
+
+	return &page
+}
@@ -0,0 +1,71 @@
+package service
+
+import(
+	"context"
+
+	"github.com/go-debit/internal/core/model"
+)
+
+// About get a financial script from go-payfee (through the cache)
+func (s *WorkerService) getScript(ctx context.Context, script string) (*model.Script, error){
+	childLogger.Debug().Str("func","getScript").Str("script", script).Send()
+
+	res, err := s.payFeeCache.Get(ctx, "script:" + script, func(ctx context.Context) (interface{}, error){
+		script_parsed, err := s.payFeeClient.GetScript(ctx, script)
+		if err != nil {
+			return nil, err
+		}
+
+		return *script_parsed, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	script_parsed := res.(model.Script)
+	return &script_parsed, nil
+}
+
+// About get a fee definition from go-payfee (through the cache)
+func (s *WorkerService) getFee(ctx context.Context, fee string) (*model.Fee, error){
+	childLogger.Debug().Str("func","getFee").Str("fee", fee).Send()
+
+	res, err := s.payFeeCache.Get(ctx, "fee:" + fee, func(ctx context.Context) (interface{}, error){
+		fee_parsed, err := s.payFeeClient.GetFee(ctx, fee)
+		if err != nil {
+			return nil, err
+		}
+
+		return *fee_parsed, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fee_parsed := res.(model.Fee)
+	return &fee_parsed, nil
+}
+
+// About the hit/miss counters of the payfee cache
+func (s *WorkerService) PayFeeCacheStats() model.CacheStats {
+	return s.payFeeCache.Stats()
+}
+
+// About invalidate the payfee cache (a key like script:script.debit or fee:tax, empty for all), only for an authenticated operator
+func (s *WorkerService) InvalidatePayFeeCache(ctx context.Context, key string) (*model.CacheStats, error){
+	childLogger.Info().Str("func","InvalidatePayFeeCache").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Str("key", key).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "service.InvalidatePayFeeCache")
+	defer span.End()
+
+	err := checkAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.payFeeCache.Invalidate(key)
+
+	res := s.payFeeCache.Stats()
+	return &res, nil
+}
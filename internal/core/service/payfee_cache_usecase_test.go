@@ -0,0 +1,27 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/go-debit/internal/core/erro"
+)
+
+func TestInvalidatePayFeeCacheOnlyForOperator(t *testing.T) {
+	test := newTestService(t, defaultTestConfig())
+
+	_, err := test.service.InvalidatePayFeeCache(identityContext(testTenant, testPerson, false), "")
+	if err != erro.ErrHTTPForbiden {
+		t.Errorf("InvalidatePayFeeCache: %v, want %v", err, erro.ErrHTTPForbiden)
+	}
+
+	// Without JWT (JWT_ENABLED=false) there is no operator
+	_, err = test.service.InvalidatePayFeeCache(tenantContext(testTenant), "")
+	if err != erro.ErrHTTPForbiden {
+		t.Errorf("InvalidatePayFeeCache without token: %v, want %v", err, erro.ErrHTTPForbiden)
+	}
+
+	_, err = test.service.InvalidatePayFeeCache(identityContext(testTenant, testPerson, true), "")
+	if err != nil {
+		t.Errorf("InvalidatePayFeeCache operator: %v", err)
+	}
+}
@@ -0,0 +1,164 @@
+package service
+
+import(
+	"time"
+	"context"
+
+	"github.com/go-debit/internal/core/erro"
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/infra/metrics"
+)
+
+// About charge the pending fees when the go-payfee is reachable again until the ctx is done
+func (s *WorkerService) PendingFeeWorker(ctx context.Context, pendingFeeConfig *model.PendingFeeConfig) {
+	childLogger.Info().Str("func","PendingFeeWorker").Interface("pendingFeeConfig", pendingFeeConfig).Send()
+
+	ticker := time.NewTicker(time.Duration(max(pendingFeeConfig.PollInterval, 1)) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			childLogger.Info().Msg("stop pending fee worker !!!")
+			return
+		case <-ticker.C:
+			count, err := s.DispatchPendingFee(ctx, pendingFeeConfig)
+			if err != nil {
+				childLogger.Error().Err(err).Msg("error dispatch pending fee")
+			}
+			if count > 0 {
+				childLogger.Info().Int("charged", count).Msg("pending fee dispatched")
+			}
+		}
+	}
+}
+
+// About charge one batch of pending fees, returns how many were charged
+func (s *WorkerService) DispatchPendingFee(ctx context.Context, pendingFeeConfig *model.PendingFeeConfig) (count int, err error){
+	childLogger.Debug().Str("func","DispatchPendingFee").Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "service.DispatchPendingFee")
+
+	// Get the database connection
+	tx, err := s.workerRepository.StartTx(ctx)
+	if err != nil {
+		span.End()
+		return 0, err
+	}
+	
+	// Handle the transaction (the metrics of the fees are recorded after the commit)
+	res_fees := []model.AccountStatementFee{}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+			if err == nil {
+				metrics.RecordFees(ctx, res_fees)
+			}
+		}
+		s.workerRepository.ReleaseTx(tx)
+		span.End()
+	}()
+
+	list_pendingFee, err := s.workerRepository.ListPendingFee(ctx, tx, pendingFeeConfig.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, pendingFee := range *list_pendingFee {
+		// The queries of the fee are scoped by the tenant of the statement (the worker has no request)
+		ctxFee := context.WithValue(ctx, "trace-request-id", pendingFee.TraceID)
+		ctxFee = context.WithValue(ctxFee, "tenant-id", pendingFee.TenantID)
+
+		// A debit reversed in the meantime is charged only on the remaining amount (its reversal did not reverse any fee)
+		// and a debit fully reversed is not charged
+		reversed, err := s.workerRepository.GetReversedAmount(ctxFee, tx, &model.AccountStatement{ID: pendingFee.FkAccountStatementID})
+		if err != nil {
+			return 0, err
+		}
+		remaining := pendingFee.Amount.Add(reversed)
+		if !remaining.IsNegative() {
+			pendingFee.Status = "CANCELLED"
+			_, err = s.workerRepository.UpdatePendingFee(ctx, tx, &pendingFee)
+			if err != nil {
+				return 0, err
+			}
+			_, err = s.workerRepository.UpdateFeeStatus(ctxFee, tx, &model.AccountStatement{ID: pendingFee.FkAccountStatementID, FeeStatus: "CANCELLED"})
+			if err != nil {
+				return 0, err
+			}
+			continue
+		}
+
+		accountStatementFee := model.AccountStatementFee{	FkAccountStatementID: pendingFee.FkAccountStatementID,
+															Currency: pendingFee.Currency,
+															Amount: remaining,
+															TenantID: pendingFee.TenantID }
+
+		list_accountStatementFee, errFee := s.calcAccountStatementFee(ctxFee, accountStatementFee)
+		
+		// The go-payfee is still unavailable (circuit breaker open), keep the remaining for the next round without spending attempts
+		if errFee == erro.ErrServiceUnavailable {
+			childLogger.Error().Err(errFee).Int("pending_fee_id", pendingFee.ID).Msg("go-payfee unavailable, pending fee postponed")
+			break
+		}
+
+		accountStatement := model.AccountStatement{ID: pendingFee.FkAccountStatementID}
+		pendingFee.Attempts = pendingFee.Attempts + 1
+		if errFee == nil {
+			feeCharged := model.AccountStatement{ID: pendingFee.FkAccountStatementID}
+			for _, new_accountStatementFee := range *list_accountStatementFee {
+				res_accountStatementFee, err := s.workerRepository.AddAccountStatementFee(ctxFee, tx, new_accountStatementFee)
+				if err != nil {
+					return 0, err
+				}
+				feeCharged.Fees = append(feeCharged.Fees, *res_accountStatementFee)
+			}
+			res_fees = append(res_fees, feeCharged.Fees...)
+
+			// Add the FeeCharged event in the outbox (same transaction)
+			if s.eventPublisher != nil {
+				res_accountStatement, err := s.workerRepository.GetAccountStatement(ctxFee, tx, &feeCharged)
+				if err != nil {
+					return 0, err
+				}
+				res_accountStatement.FeeStatus = "CHARGED"
+				res_accountStatement.Fees = feeCharged.Fees
+
+				err = s.addDomainEvent(ctxFee, tx, "FeeCharged", res_accountStatement, pendingFee.TraceID)
+				if err != nil {
+					return 0, err
+				}
+			}
+			pendingFee.Status = "CHARGED"
+			pendingFee.LastError = nil
+			accountStatement.FeeStatus = "CHARGED"
+			count = count + 1
+		} else {
+			childLogger.Error().Err(errFee).Int("pending_fee_id", pendingFee.ID).Int("attempts", pendingFee.Attempts).Msg("error charge pending fee")
+
+			pendingFee.LastError = lastError(errFee)
+			pendingFee.NextAttemptAt = time.Now().Add(retryBackoff(pendingFee.Attempts))
+			if pendingFee.Attempts >= pendingFeeConfig.MaxAttempts {
+				pendingFee.Status = "FAILED"
+				accountStatement.FeeStatus = "FAILED"
+			}
+		}
+
+		_, err = s.workerRepository.UpdatePendingFee(ctx, tx, &pendingFee)
+		if err != nil {
+			return 0, err
+		}
+
+		if accountStatement.FeeStatus != "" {
+			_, err = s.workerRepository.UpdateFeeStatus(ctxFee, tx, &accountStatement)
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return count, nil
+}
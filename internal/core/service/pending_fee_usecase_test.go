@@ -0,0 +1,130 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/go-debit/internal/core/erro"
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/infra/configuration"
+)
+
+func TestPendingFeeChargedWhenPayFeeIsBack(t *testing.T) {
+	test := newTestService(t, defaultTestConfig())
+	ctx := tenantContext(testTenant)
+	pendingFeeConfig := configuration.GetPendingFeeEnv()
+
+	test.payFee.SetError(erro.ErrServiceUnavailable)
+	res, err := test.service.AddDebit(ctx, newDebit("-100.00"), "")
+	if err != nil {
+		t.Fatalf("AddDebit: %v", err)
+	}
+	if res.FeeStatus != "PENDING" {
+		t.Fatalf("fee_status %s, want PENDING", res.FeeStatus)
+	}
+
+	test.payFee.SetError(nil)
+	count, err := test.service.DispatchPendingFee(context.Background(), &pendingFeeConfig)
+	if err != nil {
+		t.Fatalf("DispatchPendingFee: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("charged: %d, want 1", count)
+	}
+
+	debit, err := test.repository.GetDebit(ctx, &model.AccountStatement{TransactionID: res.TransactionID, Type: "DEBIT"})
+	if err != nil {
+		t.Fatalf("GetDebit: %v", err)
+	}
+	if debit.FeeStatus != "CHARGED" {
+		t.Errorf("fee_status %s, want CHARGED", debit.FeeStatus)
+	}
+}
+
+func TestPendingFeeCancelledByReversal(t *testing.T) {
+	test := newTestService(t, defaultTestConfig())
+	ctx := tenantContext(testTenant)
+	pendingFeeConfig := configuration.GetPendingFeeEnv()
+
+	test.payFee.SetError(erro.ErrServiceUnavailable)
+	res, err := test.service.AddDebit(ctx, newDebit("-100.00"), "")
+	if err != nil {
+		t.Fatalf("AddDebit: %v", err)
+	}
+
+	_, err = test.service.ReverseDebit(ctx, &model.AccountStatement{	AccountID: testAccount,
+																		TransactionID: res.TransactionID })
+	if err != nil {
+		t.Fatalf("ReverseDebit: %v", err)
+	}
+
+	test.payFee.SetError(nil)
+	count, err := test.service.DispatchPendingFee(context.Background(), &pendingFeeConfig)
+	if err != nil {
+		t.Fatalf("DispatchPendingFee: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("charged: %d, want 0", count)
+	}
+
+	debit, err := test.repository.GetDebit(ctx, &model.AccountStatement{TransactionID: res.TransactionID, Type: "DEBIT"})
+	if err != nil {
+		t.Fatalf("GetDebit: %v", err)
+	}
+	if debit.FeeStatus != "CANCELLED" {
+		t.Errorf("fee_status %s, want CANCELLED", debit.FeeStatus)
+	}
+	fees, err := test.repository.ListAccountStatementFeePerStatement(ctx, []int{debit.ID})
+	if err != nil {
+		t.Fatalf("ListAccountStatementFeePerStatement: %v", err)
+	}
+	if len(*fees) != 0 {
+		t.Errorf("fees of the reversed debit: %d, want 0", len(*fees))
+	}
+}
+
+func TestPendingFeeChargedOnRemainingAfterPartialReversal(t *testing.T) {
+	test := newTestService(t, defaultTestConfig())
+	ctx := tenantContext(testTenant)
+	pendingFeeConfig := configuration.GetPendingFeeEnv()
+
+	test.payFee.SetScript(	model.Script{Name: "script.debit", Fee: []string{"TAX"}},
+							model.Fee{Name: "TAX", Value: decimal.NewFromInt(1)})
+	test.payFee.SetError(erro.ErrServiceUnavailable)
+	res, err := test.service.AddDebit(ctx, newDebit("-100.00"), "")
+	if err != nil {
+		t.Fatalf("AddDebit: %v", err)
+	}
+
+	_, err = test.service.ReverseDebit(ctx, newReversal(res.TransactionID, "40.00"))
+	if err != nil {
+		t.Fatalf("ReverseDebit: %v", err)
+	}
+
+	test.payFee.SetError(nil)
+	count, err := test.service.DispatchPendingFee(context.Background(), &pendingFeeConfig)
+	if err != nil {
+		t.Fatalf("DispatchPendingFee: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("charged: %d, want 1", count)
+	}
+
+	debit, err := test.repository.GetDebit(ctx, &model.AccountStatement{TransactionID: res.TransactionID, Type: "DEBIT"})
+	if err != nil {
+		t.Fatalf("GetDebit: %v", err)
+	}
+	if debit.FeeStatus != "CHARGED" {
+		t.Errorf("fee_status %s, want CHARGED", debit.FeeStatus)
+	}
+	fees, err := test.repository.ListAccountStatementFeePerStatement(ctx, []int{debit.ID})
+	if err != nil {
+		t.Fatalf("ListAccountStatementFeePerStatement: %v", err)
+	}
+	// 1% of the 60.00 not reversed
+	if len(*fees) != 1 || !(*fees)[0].Amount.Equal(decimal.RequireFromString("-0.60")) {
+		t.Errorf("fees %v, want one of -0.60", *fees)
+	}
+}
@@ -0,0 +1,183 @@
+package service
+
+import(
+	"fmt"
+	"context"
+	"errors"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/erro"
+)
+
+// About reverse a debit (total or partial)
+// A REVERSAL statement is linked to the original debit, the fees not yet reversed are reversed in the proportion of the remaining amount
+// and the opposite amount is posted to the account balance (by the outbox)
+func (s *WorkerService) ReverseDebit(ctx context.Context, reversal *model.AccountStatement) (res_reversal *model.AccountStatement, err error){
+	childLogger.Info().Str("func","ReverseDebit").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Interface("reversal", reversal).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "service.ReverseDebit")
+	trace_id := fmt.Sprintf("%v",ctx.Value("trace-request-id"))
+
+	// Get the database connection
+	tx, err := s.workerRepository.StartTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Handle the transaction
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			if errCommit := tx.Commit(ctx); errCommit != nil {
+				childLogger.Error().Err(errCommit).Msg("error commit")
+				res_reversal, err = nil, errors.New(errCommit.Error())
+			}
+		}
+		s.workerRepository.ReleaseTx(tx)
+		span.End()
+	}()
+
+	// Business rules
+	if reversal.Amount.IsNegative() {
+		return nil, erro.ErrInvalidAmount
+	}
+
+	// Get the original debit (locked until the end of the transaction)
+	debit, err := s.workerRepository.GetDebitForUpdate(ctx, tx, &model.AccountStatement{TransactionID: reversal.TransactionID,
+																							Type: "DEBIT"})
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the Account ID from Account-service
+	account_parsed, err := s.accountClient.GetAccount(ctx, reversal.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	err = checkTenant(ctx, account_parsed.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	err = checkAccountOwner(ctx, account_parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	// Business rule
+	if account_parsed.ID != debit.FkAccountID {
+		return nil, erro.ErrTransInvalid
+	}
+
+	// Check the amount still available to reverse
+	reversed, err := s.workerRepository.GetReversedAmount(ctx, tx, debit)
+	if err != nil {
+		return nil, err
+	}
+	remaining := debit.Amount.Neg().Sub(reversed)
+	if !remaining.IsPositive() {
+		return nil, erro.ErrAlreadyReversed
+	}
+	if reversal.Amount.IsZero() {
+		reversal.Amount = remaining
+	}
+	if reversal.Amount.GreaterThan(remaining) {
+		return nil, erro.ErrReversalExceeded
+	}
+	if !s.validMoneyScale(reversal.Amount, debit.Currency) {
+		return nil, erro.ErrInvalidAmount
+	}
+
+	// Get transaction UUID (generated here, the tx already holds a connection of the pool)
+	res_uuid := newTransactionID()
+
+	// Add the reversal
+	reversal.FkAccountID = debit.FkAccountID
+	reversal.Type = "REVERSAL"
+	reversal.Currency = debit.Currency
+	reversal.TenantID = debit.TenantID
+	reversal.Subject = subjectFromContext(ctx)
+	reversal.TransactionID = res_uuid
+	reversal.ReversedStatementID = &debit.ID
+
+	res, err := s.workerRepository.AddReversal(ctx, tx, reversal)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cancel the fees still pending (go-payfee was unavailable at the debit) when the debit is fully reversed, the worker must not charge it
+	// After a partial reversal the fees stay pending and the worker charges them on the remaining amount
+	// It runs before listing the fees, so a fee charged by the worker in the meantime is reversed below
+	if reversal.Amount.Equal(remaining) {
+		cancelled, err := s.workerRepository.CancelPendingFee(ctx, tx, debit)
+		if err != nil {
+			return nil, err
+		}
+		if cancelled > 0 {
+			_, err = s.workerRepository.UpdateFeeStatus(ctx, tx, &model.AccountStatement{ID: debit.ID, FeeStatus: "CANCELLED"})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Reverse the fees not yet reversed by the previous reversals in the proportion of the remaining amount
+	// (the reversal that completes the debit reverses exactly the rest, so the fee is never reversed twice)
+	list_accountStatementFee, err := s.workerRepository.ListAccountStatementFee(ctx, tx, debit)
+	if err != nil {
+		return nil, err
+	}
+	list_reversedFee, err := s.workerRepository.ListReversedFee(ctx, tx, debit)
+	if err != nil {
+		return nil, err
+	}
+	// the fees of the debit are negative and the reversed fees positive, both are summed per type of fee
+	remainingFees := map[string]decimal.Decimal{}
+	for _, v_accountStatementFee := range *list_accountStatementFee {
+		remainingFees[v_accountStatementFee.TypeFee] = remainingFees[v_accountStatementFee.TypeFee].Sub(v_accountStatementFee.Amount)
+	}
+	for _, v_reversedFee := range *list_reversedFee {
+		remainingFees[v_reversedFee.TypeFee] = remainingFees[v_reversedFee.TypeFee].Sub(v_reversedFee.Amount)
+	}
+	for _, v_accountStatementFee := range *list_accountStatementFee {
+		remainingFee, found := remainingFees[v_accountStatementFee.TypeFee]
+		if !found {
+			continue
+		}
+		delete(remainingFees, v_accountStatementFee.TypeFee)
+		remainingFee = decimal.Max(remainingFee, decimal.Zero)
+
+		new_accountStatementFee := v_accountStatementFee
+		new_accountStatementFee.FkAccountStatementID = res.ID
+		new_accountStatementFee.Amount = remainingFee
+		if reversal.Amount.LessThan(remaining) {
+			new_accountStatementFee.Amount = decimal.Min(s.roundMoney(remainingFee.Mul(reversal.Amount).Div(remaining), v_accountStatementFee.Currency), 
+															remainingFee)
+		}
+		_, err = s.workerRepository.AddAccountStatementFee(ctx, tx, new_accountStatementFee)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Add the opposite amount posting in the outbox (same transaction)
+	outbox, err := newBalancePosting(reversal, trace_id)
+	if err != nil {
+		return nil, err
+	}
+	_, err = s.workerRepository.AddOutbox(ctx, tx, outbox)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add the DebitReversed event in the outbox (same transaction)
+	err = s.addDomainEvent(ctx, tx, "DebitReversed", res, trace_id)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
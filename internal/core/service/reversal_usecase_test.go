@@ -0,0 +1,158 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/go-debit/internal/core/erro"
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/infra/configuration"
+)
+
+func newReversal(transactionID *string, amount string) *model.AccountStatement {
+	return &model.AccountStatement{	AccountID: testAccount,
+									TransactionID: transactionID,
+									Amount: decimal.RequireFromString(amount) }
+}
+
+func TestReverseDebit(t *testing.T) {
+	test := newTestService(t, defaultTestConfig())
+	ctx := tenantContext(testTenant)
+	outboxConfig := configuration.GetOutboxEnv()
+
+	debit, err := test.service.AddDebit(ctx, newDebit("-100.00"), "")
+	if err != nil {
+		t.Fatalf("AddDebit: %v", err)
+	}
+
+	_, err = test.service.ReverseDebit(ctx, newReversal(debit.TransactionID, "-10.00"))
+	if err != erro.ErrInvalidAmount {
+		t.Errorf("negative reversal: %v, want %v", err, erro.ErrInvalidAmount)
+	}
+	_, err = test.service.ReverseDebit(ctx, newReversal(debit.TransactionID, "150.00"))
+	if err != erro.ErrReversalExceeded {
+		t.Errorf("over the debit: %v, want %v", err, erro.ErrReversalExceeded)
+	}
+
+	partial, err := test.service.ReverseDebit(ctx, newReversal(debit.TransactionID, "40.00"))
+	if err != nil {
+		t.Fatalf("partial reversal: %v", err)
+	}
+	if partial.Type != "REVERSAL" || *partial.ReversedStatementID != debit.ID {
+		t.Errorf("reversal %s of %d, want REVERSAL of %d", partial.Type, *partial.ReversedStatementID, debit.ID)
+	}
+
+	// Without amount the remaining is reversed
+	remaining, err := test.service.ReverseDebit(ctx, newReversal(debit.TransactionID, "0"))
+	if err != nil {
+		t.Fatalf("remaining reversal: %v", err)
+	}
+	if !remaining.Amount.Equal(decimal.NewFromInt(60)) {
+		t.Errorf("remaining %s, want 60", remaining.Amount)
+	}
+	_, err = test.service.ReverseDebit(ctx, newReversal(debit.TransactionID, "0"))
+	if err != erro.ErrAlreadyReversed {
+		t.Errorf("reversed again: %v, want %v", err, erro.ErrAlreadyReversed)
+	}
+
+	// The debit and the opposite amounts are posted to the balance
+	_, err = test.service.DispatchOutbox(context.Background(), &outboxConfig)
+	if err != nil {
+		t.Fatalf("DispatchOutbox: %v", err)
+	}
+	total := decimal.Zero
+	for _, posting := range test.account.Postings() {
+		total = total.Add(posting.Amount)
+	}
+	if len(test.account.Postings()) != 3 || !total.IsZero() {
+		t.Errorf("postings %d with total %s, want 3 with total 0", len(test.account.Postings()), total)
+	}
+}
+
+func TestReverseDebitOfAnotherAccount(t *testing.T) {
+	test := newTestService(t, defaultTestConfig())
+	ctx := tenantContext(testTenant)
+
+	test.account.SetAccount(	model.Account{ID: 2, AccountID: "ACC-2", PersonID: testPerson, TenantID: testTenant},
+								model.AccountBalance{AccountID: "ACC-2", Currency: "BRL", Amount: decimal.NewFromInt(1000), TenantID: testTenant})
+
+	debit, err := test.service.AddDebit(ctx, newDebit("-100.00"), "")
+	if err != nil {
+		t.Fatalf("AddDebit: %v", err)
+	}
+
+	reversal := newReversal(debit.TransactionID, "10.00")
+	reversal.AccountID = "ACC-2"
+	_, err = test.service.ReverseDebit(ctx, reversal)
+	if err != erro.ErrTransInvalid {
+		t.Errorf("reversal by another account: %v, want %v", err, erro.ErrTransInvalid)
+	}
+}
+
+// About the sum of the fees of the statements
+func (test *testService) sumFees(t *testing.T, ctx context.Context, statements ...*model.AccountStatement) decimal.Decimal {
+	ids := []int{}
+	for _, statement := range statements {
+		ids = append(ids, statement.ID)
+	}
+	fees, err := test.repository.ListAccountStatementFeePerStatement(ctx, ids)
+	if err != nil {
+		t.Fatalf("ListAccountStatementFeePerStatement: %v", err)
+	}
+	total := decimal.Zero
+	for _, fee := range *fees {
+		total = total.Add(fee.Amount)
+	}
+	return total
+}
+
+func TestReverseDebitFeesNotReversedTwice(t *testing.T) {
+	test := newTestService(t, defaultTestConfig())
+	ctx := tenantContext(testTenant)
+
+	// The fee of 0.01 is rounded up on the first half, nothing is left for the second
+	debit, err := test.service.AddDebit(ctx, newDebit("-100.00"), "")
+	if err != nil {
+		t.Fatalf("AddDebit: %v", err)
+	}
+	first, err := test.service.ReverseDebit(ctx, newReversal(debit.TransactionID, "50.00"))
+	if err != nil {
+		t.Fatalf("ReverseDebit: %v", err)
+	}
+	second, err := test.service.ReverseDebit(ctx, newReversal(debit.TransactionID, "50.00"))
+	if err != nil {
+		t.Fatalf("ReverseDebit: %v", err)
+	}
+	if total := test.sumFees(t, ctx, debit, first, second); !total.IsZero() {
+		t.Errorf("fees of the debit and its reversals %s, want 0", total)
+	}
+}
+
+func TestReverseDebitFeesInProportion(t *testing.T) {
+	test := newTestService(t, defaultTestConfig())
+	ctx := tenantContext(testTenant)
+
+	// With a fee of 1% each reversal reverses its part of the fee not yet reversed, the last one the rest
+	test.payFee.SetScript(	model.Script{Name: "script.debit", Fee: []string{"TAX"}},
+							model.Fee{Name: "TAX", Value: decimal.NewFromInt(1)})
+	debit, err := test.service.AddDebit(ctx, newDebit("-100.00"), "")
+	if err != nil {
+		t.Fatalf("AddDebit: %v", err)
+	}
+	reversals := []*model.AccountStatement{debit}
+	for _, amount := range []string{"30.00", "30.00", "0"} {
+		reversal, err := test.service.ReverseDebit(ctx, newReversal(debit.TransactionID, amount))
+		if err != nil {
+			t.Fatalf("ReverseDebit %s: %v", amount, err)
+		}
+		reversals = append(reversals, reversal)
+	}
+	if fee := test.sumFees(t, ctx, reversals[1]); !fee.Equal(decimal.RequireFromString("0.30")) {
+		t.Errorf("fee of the first reversal %s, want 0.30", fee)
+	}
+	if total := test.sumFees(t, ctx, reversals...); !total.IsZero() {
+		t.Errorf("fees of the debit and its reversals %s, want 0", total)
+	}
+}
@@ -0,0 +1,70 @@
+package service
+
+import(
+	"context"
+
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/erro"
+)
+
+// About search the account statements (all types) with filters
+func (s *WorkerService) SearchStatement(ctx context.Context, filter *model.StatementFilter, pagination *model.Pagination) (*model.AccountStatementPage, error){
+	childLogger.Info().Str("func","SearchStatement").Interface("trace-resquest-id", ctx.Value("trace-request-id")).Interface("filter", filter).Interface("pagination", pagination).Send()
+
+	// Trace
+	span := tracerProvider.Span(ctx, "service.SearchStatement")
+	defer span.End()
+
+	// Business rules
+	if filter.DateStart != nil && filter.DateEnd != nil && filter.DateEnd.Before(*filter.DateStart) {
+		return nil, erro.ErrInvalidFilter
+	}
+	if filter.AmountMin != nil && filter.AmountMax != nil && filter.AmountMax.LessThan(*filter.AmountMin) {
+		return nil, erro.ErrInvalidFilter
+	}
+
+	offset, err := prepareOffsetPagination(pagination)
+	if err != nil {
+		return nil, err
+	}
+	filter.Offset = offset
+
+	// The search is scoped by the tenant of the request (another tenant in the filter is rejected)
+	if filter.TenantID != "" {
+		err = checkTenant(ctx, filter.TenantID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	filter.TenantID = tenantFromContext(ctx)
+
+	// Get the Account ID from Account-service (the account is optional, only for an operator)
+	if filter.AccountID == "" {
+		err = checkOperator(ctx)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		account_parsed, err := s.accountClient.GetAccount(ctx, filter.AccountID)
+		if err != nil {
+			return nil, err
+		}
+		err = checkTenant(ctx, account_parsed.TenantID)
+		if err != nil {
+			return nil, err
+		}
+		err = checkAccountOwner(ctx, account_parsed)
+		if err != nil {
+			return nil, err
+		}
+
+		filter.FkAccountID = account_parsed.ID
+	}
+
+	res, err := s.workerRepository.SearchStatement(ctx, filter, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildOffsetPage(*res, pagination, offset), nil
+}
@@ -2,23 +2,55 @@ package service
 
 import(
 	"github.com/go-debit/internal/core/model"
-	"github.com/go-debit/internal/adapter/database"
+	"github.com/go-debit/internal/core/port"
+	"github.com/go-debit/internal/infra/circuitbreaker"
+	"github.com/go-debit/internal/infra/cache"
 	"github.com/rs/zerolog/log"
 )
 
 var childLogger = log.With().Str("component","go-debit").Str("package","internal.core.service").Logger()
 
 type WorkerService struct {
-	workerRepository *database.WorkerRepository
-	apiService		[]model.ApiService
+	workerRepository port.WorkerRepository
+	accountClient	port.AccountClient
+	payFeeClient	port.PayFeeClient
+	moneyConfig		*model.MoneyConfig
+	overdraftConfig	*model.OverdraftConfig
+	debitLimitConfig	*model.DebitLimitConfig
+	batchConfig		*model.BatchConfig
+	circuitBreakers	*circuitbreaker.CircuitBreakerRegistry
+	payFeeCache		*cache.Cache
+	eventConfig		*model.EventConfig
+	eventPublisher	EventPublisher
+	healthConfig	*model.HealthConfig
 }
 
-func NewWorkerService(	workerRepository *database.WorkerRepository,
-						apiService		[]model.ApiService) *WorkerService{
+func NewWorkerService(	workerRepository port.WorkerRepository,
+						accountClient	port.AccountClient,
+						payFeeClient	port.PayFeeClient,
+						moneyConfig		*model.MoneyConfig,
+						overdraftConfig	*model.OverdraftConfig,
+						debitLimitConfig	*model.DebitLimitConfig,
+						batchConfig		*model.BatchConfig,
+						circuitBreakers	*circuitbreaker.CircuitBreakerRegistry,
+						payFeeCache		*cache.Cache,
+						eventConfig		*model.EventConfig,
+						eventPublisher	EventPublisher,
+						healthConfig	*model.HealthConfig) *WorkerService{
 	childLogger.Info().Str("func","NewWorkerService").Send()
 
 	return &WorkerService{
 		workerRepository: workerRepository,
-		apiService: apiService,
+		accountClient: accountClient,
+		payFeeClient: payFeeClient,
+		moneyConfig: moneyConfig,
+		overdraftConfig: overdraftConfig,
+		debitLimitConfig: debitLimitConfig,
+		batchConfig: batchConfig,
+		circuitBreakers: circuitBreakers,
+		payFeeCache: payFeeCache,
+		eventConfig: eventConfig,
+		eventPublisher: eventPublisher,
+		healthConfig: healthConfig,
 	}
 }
\ No newline at end of file
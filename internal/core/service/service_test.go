@@ -0,0 +1,116 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/go-debit/internal/adapter/client"
+	"github.com/go-debit/internal/adapter/memory"
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/port"
+	"github.com/go-debit/internal/core/service"
+	"github.com/go-debit/internal/infra/cache"
+	"github.com/go-debit/internal/infra/circuitbreaker"
+	"github.com/go-debit/internal/infra/configuration"
+)
+
+const (
+	testTenant	= "TENANT-1"
+	testPerson	= "PERSON-1"
+	testAccount	= "ACC-1"
+)
+
+// The service of the tests, wired with the in-memory repository and the fake clients
+// The configs are the defaults of the configuration (no .env in the package directory)
+type testService struct {
+	service		*service.WorkerService
+	repository	*memory.MemoryRepository
+	account		*client.FakeAccountClient
+	payFee		*client.FakePayFeeClient
+	config		testConfig
+}
+
+type testConfig struct {
+	money		model.MoneyConfig
+	overdraft	model.OverdraftConfig
+	debitLimit	model.DebitLimitConfig
+	batch		model.BatchConfig
+	event		model.EventConfig
+	health		model.HealthConfig
+}
+
+func defaultTestConfig() testConfig {
+	return testConfig{
+		money: configuration.GetMoneyEnv(),
+		overdraft: configuration.GetOverdraftEnv(),
+		debitLimit: configuration.GetDebitLimitEnv(),
+		batch: configuration.GetBatchEnv(),
+		event: configuration.GetEventEnv(),
+		health: configuration.GetHealthEnv(),
+	}
+}
+
+func newTestService(t *testing.T, config testConfig) *testService {
+	t.Helper()
+	return newTestServiceWithRepository(t, config, memory.NewMemoryRepository())
+}
+
+// About the service over another repository (ex: the memory repository behind a pool of connections)
+func newTestServiceWithRepository(t *testing.T, config testConfig, repository port.WorkerRepository) *testService {
+	t.Helper()
+
+	accountClient := client.NewFakeAccountClient()
+	accountClient.SetAccount(	model.Account{ID: 1, AccountID: testAccount, PersonID: testPerson, TenantID: testTenant},
+								model.AccountBalance{AccountID: testAccount, Currency: "BRL", Amount: decimal.NewFromInt(1000), TenantID: testTenant})
+
+	payFeeClient := client.NewFakePayFeeClient()
+	payFeeClient.SetScript(	model.Script{Name: "script.debit", Fee: []string{"TAX"}},
+							model.Fee{Name: "TAX", Value: decimal.RequireFromString("0.01")})
+
+	defaultCircuitBreakerConfig, circuitBreakerConfig := configuration.GetCircuitBreakerEnv(nil)
+	circuitBreakers := circuitbreaker.NewCircuitBreakerRegistry(defaultCircuitBreakerConfig, circuitBreakerConfig)
+	payFeeCache := cache.NewCache(configuration.GetCacheEnv())
+
+	workerService := service.NewWorkerService(	repository,
+												accountClient,
+												payFeeClient,
+												&config.money,
+												&config.overdraft,
+												&config.debitLimit,
+												&config.batch,
+												circuitBreakers,
+												payFeeCache,
+												&config.event,
+												nil,
+												&config.health)
+
+	memoryRepository, _ := repository.(*memory.MemoryRepository)
+	return &testService{	service: workerService,
+							repository: memoryRepository,
+							account: accountClient,
+							payFee: payFeeClient,
+							config: config }
+}
+
+// About the ctx of a request of the tenant (without JWT, like JWT_ENABLED=false)
+func tenantContext(tenantID string) context.Context {
+	ctx := context.WithValue(context.Background(), "trace-request-id", "test")
+	return context.WithValue(ctx, "tenant-id", tenantID)
+}
+
+// About the ctx of a request authenticated by a JWT
+func identityContext(tenantID string, personID string, operator bool) context.Context {
+	ctx := tenantContext(tenantID)
+	ctx = context.WithValue(ctx, "subject", "user-" + personID)
+	ctx = context.WithValue(ctx, "person-id", personID)
+	return context.WithValue(ctx, "operator", operator)
+}
+
+func newDebit(amount string) *model.AccountStatement {
+	return &model.AccountStatement{	AccountID: testAccount,
+									Type: "DEBIT",
+									Currency: "BRL",
+									Amount: decimal.RequireFromString(amount) }
+}
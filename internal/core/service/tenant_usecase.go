@@ -0,0 +1,80 @@
+package service
+
+import(
+	"context"
+	"fmt"
+
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/erro"
+)
+
+// About the tenant of the request, resolved by the tenant middleware from the authenticated identity
+func tenantFromContext(ctx context.Context) string {
+	if ctx.Value("tenant-id") == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v",ctx.Value("tenant-id"))
+}
+
+// About the subject of the JWT of the request, recorded on the debit for audit (empty without authentication)
+func subjectFromContext(ctx context.Context) string {
+	if ctx.Value("subject") == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v",ctx.Value("subject"))
+}
+
+// About reject the access to the data of another tenant (an account, a body or a filter)
+func checkTenant(ctx context.Context, tenantID string) error {
+	tenant := tenantFromContext(ctx)
+	if tenant == "" || tenantID != tenant {
+		childLogger.Error().Interface("trace-resquest-id", ctx.Value("trace-request-id")).Str("tenant_id", tenantID).Str("tenant", tenant).Msg("cross tenant access rejected")
+		return erro.ErrHTTPForbiden
+	}
+	return nil
+}
+
+// About the caller authenticated by a JWT (without authentication the ownership is not checked)
+func authenticated(ctx context.Context) bool {
+	return subjectFromContext(ctx) != ""
+}
+
+// About the caller holds the operator role, not restricted to its own accounts
+func isOperator(ctx context.Context) bool {
+	operator, ok := ctx.Value("operator").(bool)
+	return ok && operator
+}
+
+// About reject the access to an account of another person, unless the caller is an operator
+func checkAccountOwner(ctx context.Context, account *model.Account) error {
+	if !authenticated(ctx) || isOperator(ctx) {
+		return nil
+	}
+
+	personID, _ := ctx.Value("person-id").(string)
+	if personID == "" || personID != account.PersonID {
+		childLogger.Error().Interface("trace-resquest-id", ctx.Value("trace-request-id")).Str("subject", subjectFromContext(ctx)).Str("account_id", account.AccountID).Msg("access to an account of another person rejected")
+		return erro.ErrHTTPForbiden
+	}
+	return nil
+}
+
+// About reject a request not addressed to an account (ex: get by transaction id), unless the caller is an operator
+func checkOperator(ctx context.Context) error {
+	if !authenticated(ctx) || isOperator(ctx) {
+		return nil
+	}
+
+	childLogger.Error().Interface("trace-resquest-id", ctx.Value("trace-request-id")).Str("subject", subjectFromContext(ctx)).Msg("request without account rejected for a non operator")
+	return erro.ErrHTTPForbiden
+}
+
+// About reject an admin request unless the caller is an operator authenticated by a JWT (always rejected with JWT_ENABLED=false)
+func checkAdmin(ctx context.Context) error {
+	if authenticated(ctx) && isOperator(ctx) {
+		return nil
+	}
+
+	childLogger.Error().Interface("trace-resquest-id", ctx.Value("trace-request-id")).Str("subject", subjectFromContext(ctx)).Msg("admin request rejected without an authenticated operator")
+	return erro.ErrHTTPForbiden
+}
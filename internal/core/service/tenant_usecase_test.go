@@ -0,0 +1,121 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/go-debit/internal/core/erro"
+	"github.com/go-debit/internal/core/model"
+)
+
+func TestTenantCheck(t *testing.T) {
+	test := newTestService(t, defaultTestConfig())
+
+	debit, err := test.service.AddDebit(tenantContext(testTenant), newDebit("-10.00"), "")
+	if err != nil {
+		t.Fatalf("AddDebit: %v", err)
+	}
+
+	// The account of TENANT-1 by a request of TENANT-2
+	ctx := tenantContext("TENANT-2")
+	_, err = test.service.AddDebit(ctx, newDebit("-10.00"), "")
+	if err != erro.ErrHTTPForbiden {
+		t.Errorf("AddDebit of another tenant: %v, want %v", err, erro.ErrHTTPForbiden)
+	}
+	_, err = test.service.ListDebit(ctx, &model.AccountStatement{AccountID: testAccount}, &model.Pagination{})
+	if err != erro.ErrHTTPForbiden {
+		t.Errorf("ListDebit of another tenant: %v, want %v", err, erro.ErrHTTPForbiden)
+	}
+	// The debits of another tenant are not found
+	_, err = test.service.ReverseDebit(ctx, newReversal(debit.TransactionID, "0"))
+	if err != erro.ErrNotFound {
+		t.Errorf("ReverseDebit of another tenant: %v, want %v", err, erro.ErrNotFound)
+	}
+	_, err = test.service.GetDebit(ctx, &model.AccountStatement{TransactionID: debit.TransactionID})
+	if err != erro.ErrNotFound {
+		t.Errorf("GetDebit of another tenant: %v, want %v", err, erro.ErrNotFound)
+	}
+
+	// Another tenant in the body
+	other := newDebit("-10.00")
+	other.TenantID = "TENANT-2"
+	_, err = test.service.AddDebit(tenantContext(testTenant), other, "")
+	if err != erro.ErrHTTPForbiden {
+		t.Errorf("AddDebit with another tenant in the body: %v, want %v", err, erro.ErrHTTPForbiden)
+	}
+
+	if postings := test.balancePostings(); postings != 1 {
+		t.Errorf("postings: %d, want 1", postings)
+	}
+}
+
+func TestAccountOwnerCheck(t *testing.T) {
+	test := newTestService(t, defaultTestConfig())
+
+	debit, err := test.service.AddDebit(identityContext(testTenant, testPerson, false), newDebit("-10.00"), "")
+	if err != nil {
+		t.Fatalf("AddDebit by the owner: %v", err)
+	}
+	if debit.Subject != "user-" + testPerson {
+		t.Errorf("subject %q, want %q", debit.Subject, "user-" + testPerson)
+	}
+
+	// Another person of the same tenant
+	ctx := identityContext(testTenant, "OTHER", false)
+	_, err = test.service.AddDebit(ctx, newDebit("-10.00"), "")
+	if err != erro.ErrHTTPForbiden {
+		t.Errorf("AddDebit by another person: %v, want %v", err, erro.ErrHTTPForbiden)
+	}
+	_, err = test.service.ListDebit(ctx, &model.AccountStatement{AccountID: testAccount}, &model.Pagination{})
+	if err != erro.ErrHTTPForbiden {
+		t.Errorf("ListDebit by another person: %v, want %v", err, erro.ErrHTTPForbiden)
+	}
+	_, err = test.service.ReverseDebit(ctx, newReversal(debit.TransactionID, "0"))
+	if err != erro.ErrHTTPForbiden {
+		t.Errorf("ReverseDebit by another person: %v, want %v", err, erro.ErrHTTPForbiden)
+	}
+
+	// The debit by transaction id is not addressed by account, only for an operator
+	_, err = test.service.GetDebit(identityContext(testTenant, testPerson, false), &model.AccountStatement{TransactionID: debit.TransactionID})
+	if err != erro.ErrHTTPForbiden {
+		t.Errorf("GetDebit by the owner: %v, want %v", err, erro.ErrHTTPForbiden)
+	}
+
+	// An operator is not restricted to its own accounts
+	operator := identityContext(testTenant, "OTHER", true)
+	_, err = test.service.GetDebit(operator, &model.AccountStatement{TransactionID: debit.TransactionID})
+	if err != nil {
+		t.Errorf("GetDebit by an operator: %v", err)
+	}
+	_, err = test.service.ReverseDebit(operator, newReversal(debit.TransactionID, "0"))
+	if err != nil {
+		t.Errorf("ReverseDebit by an operator: %v", err)
+	}
+}
+
+func TestAccountClientError(t *testing.T) {
+	test := newTestService(t, defaultTestConfig())
+	ctx := tenantContext(testTenant)
+
+	unknown := newDebit("-10.00")
+	unknown.AccountID = "ACC-UNKNOWN"
+	_, err := test.service.AddDebit(ctx, unknown, "")
+	if err != erro.ErrNotFound {
+		t.Errorf("unknown account: %v, want %v", err, erro.ErrNotFound)
+	}
+
+	test.account.SetError(erro.ErrServiceUnavailable)
+	_, err = test.service.AddDebit(ctx, newDebit("-10.00"), "key-1")
+	if err != erro.ErrServiceUnavailable {
+		t.Errorf("go-account unavailable: %v, want %v", err, erro.ErrServiceUnavailable)
+	}
+	if postings := test.balancePostings(); postings != 0 {
+		t.Errorf("postings: %d, want 0", postings)
+	}
+
+	// The idempotency key was not kept, the retry is a new debit
+	test.account.SetError(nil)
+	_, err = test.service.AddDebit(ctx, newDebit("-10.00"), "key-1")
+	if err != nil {
+		t.Errorf("retry after go-account is back: %v", err)
+	}
+}
@@ -0,0 +1,273 @@
+package auth
+
+import (
+	"os"
+	"sync"
+	"time"
+	"context"
+	"math/big"
+	"net/http"
+	"crypto/rsa"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/json"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/erro"
+)
+
+// About a JSON Web Key (only the RSA and the EC P-256 public keys are used)
+type jsonWebKey struct {
+	Kty		string	`json:"kty"`
+	Kid		string	`json:"kid"`
+	Use		string	`json:"use"`
+	N		string	`json:"n"`
+	E		string	`json:"e"`
+	Crv		string	`json:"crv"`
+	X		string	`json:"x"`
+	Y		string	`json:"y"`
+}
+
+type jsonWebKeySet struct {
+	Keys	[]jsonWebKey	`json:"keys"`
+}
+
+// The min wait between two loads of the url (a failed load is not retried before it)
+const jwksRetryInterval = time.Minute
+
+// About the public keys of the JWKS by kid, loaded from a file or an url
+// The keys of the url are reloaded in background after JwksRefresh seconds, or when a token has an unknown kid
+// One load runs at a time, at most once a minute, and the keys in use are served meanwhile
+type KeySet struct {
+	jwtConfig	*model.JwtConfig
+	client		*http.Client
+	mutex		sync.RWMutex
+	keys		map[string]interface{}
+	loadedAt	time.Time
+	attemptedAt	time.Time
+	refreshing	chan struct{}
+}
+
+func NewKeySet(ctx context.Context, jwtConfig *model.JwtConfig) (*KeySet, error) {
+	childLogger.Info().Str("func","NewKeySet").Send()
+
+	keySet := KeySet{
+		jwtConfig: jwtConfig,
+		client: &http.Client{	Transport: otelhttp.NewTransport(&http.Transport{}),
+								Timeout: time.Second * 10 },
+	}
+
+	keySet.attemptedAt = time.Now()
+	err := keySet.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &keySet, nil
+}
+
+// About read the JWKS (the url has precedence over the file)
+func (k *KeySet) read(ctx context.Context) ([]byte, error) {
+	if k.jwtConfig.JwksUrl == "" {
+		data, err := os.ReadFile(k.jwtConfig.JwksFile)
+		if err != nil {
+			return nil, errors.New(err.Error())
+		}
+		return data, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, k.jwtConfig.JwksUrl, nil)
+	if err != nil {
+		return nil, errors.New(err.Error())
+	}
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return nil, errors.New(err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: jwks url status %d", erro.ErrJwks, resp.StatusCode)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, 1024 * 1024))
+}
+
+// About load the keys of the JWKS (the keys in use are kept when the load fails)
+func (k *KeySet) load(ctx context.Context) error {
+	childLogger.Info().Str("func","load").Str("jwks_file", k.jwtConfig.JwksFile).Str("jwks_url", k.jwtConfig.JwksUrl).Send()
+
+	data, err := k.read(ctx)
+	if err != nil {
+		return err
+	}
+	keys, err := parseJsonWebKeySet(data)
+	if err != nil {
+		return err
+	}
+
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	k.keys = keys
+	k.loadedAt = time.Now()
+
+	return nil
+}
+
+// About start a load of the url in background, not when a load is running (single flight) or in the jwksRetryInterval of the last attempt
+// Returns a channel closed at the end of the load (nil when no load runs)
+func (k *KeySet) refresh() chan struct{} {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	if k.refreshing != nil {
+		return k.refreshing
+	}
+	if time.Since(k.attemptedAt) < jwksRetryInterval {
+		return nil
+	}
+
+	done := make(chan struct{})
+	k.refreshing = done
+	k.attemptedAt = time.Now()
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), k.client.Timeout)
+		defer cancel()
+
+		err := k.load(ctx)
+		if err != nil {
+			childLogger.Error().Err(err).Msg("error reload jwks, keeping the keys in use")
+		}
+
+		k.mutex.Lock()
+		k.refreshing = nil
+		k.mutex.Unlock()
+		close(done)
+	}()
+
+	return done
+}
+
+// About the public key of a kid (an empty kid is accepted when the JWKS has a single key)
+// A stale JWKS does not delay the request, only an unknown kid waits for the load (bounded by the ctx of the request)
+func (k *KeySet) Key(ctx context.Context, kid string) (interface{}, error) {
+	k.mutex.RLock()
+	key, ok := k.lookup(kid)
+	age := time.Since(k.loadedAt)
+	k.mutex.RUnlock()
+
+	if k.jwtConfig.JwksUrl != "" {
+		if age > time.Duration(k.jwtConfig.JwksRefresh) * time.Second {
+			k.refresh()
+		}
+		if !ok {
+			if done := k.refresh(); done != nil {
+				select {
+				case <-done:
+				case <-ctx.Done():
+				}
+				k.mutex.RLock()
+				key, ok = k.lookup(kid)
+				k.mutex.RUnlock()
+			}
+		}
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown kid %q", erro.ErrInvalidToken, kid)
+	}
+
+	return key, nil
+}
+
+func (k *KeySet) lookup(kid string) (interface{}, bool) {
+	if kid == "" && len(k.keys) == 1 {
+		for _, key := range k.keys {
+			return key, true
+		}
+	}
+	key, ok := k.keys[kid]
+	return key, ok
+}
+
+// About parse the public keys of a JWKS (the keys not used for signature are skipped)
+func parseJsonWebKeySet(data []byte) (map[string]interface{}, error) {
+	var jwks jsonWebKeySet
+	err := json.Unmarshal(data, &jwks)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", erro.ErrJwks, err.Error())
+	}
+
+	keys := map[string]interface{}{}
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			childLogger.Error().Err(err).Str("kid", jwk.Kid).Msg("jwk skipped")
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	if len(keys) == 0 {
+		return nil, erro.ErrJwks
+	}
+
+	return keys, nil
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errors.New(err.Error())
+	}
+	if len(data) == 0 {
+		return nil, erro.ErrJwks
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+// About the RSA or the EC P-256 public key of a jwk
+func (jwk jsonWebKey) publicKey() (interface{}, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := decodeBigInt(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1 << 31 {
+			return nil, fmt.Errorf("%w: rsa exponent", erro.ErrJwks)
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		if jwk.Crv != "P-256" {
+			return nil, fmt.Errorf("%w: curve %s", erro.ErrJwks, jwk.Crv)
+		}
+		x, err := decodeBigInt(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !elliptic.P256().IsOnCurve(x, y) {
+			return nil, fmt.Errorf("%w: point not on curve", erro.ErrJwks)
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("%w: kty %s", erro.ErrJwks, jwk.Kty)
+	}
+}
@@ -0,0 +1,149 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-debit/internal/core/model"
+)
+
+// About a JWKS url that counts the loads, serves the kids of keys and fails or delays on demand
+type jwksServer struct {
+	mutex	sync.Mutex
+	kids	[]string
+	fail	bool
+	delay	time.Duration
+	loads	atomic.Int32
+	key		*rsa.PrivateKey
+}
+
+func (j *jwksServer) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	j.loads.Add(1)
+
+	j.mutex.Lock()
+	kids, fail, delay := j.kids, j.fail, j.delay
+	j.mutex.Unlock()
+
+	time.Sleep(delay)
+	if fail {
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	jwks := jsonWebKeySet{}
+	for _, kid := range kids {
+		jwks.Keys = append(jwks.Keys, jsonWebKey{	Kty: "RSA",
+													Kid: kid,
+													Use: "sig",
+													N: base64.RawURLEncoding.EncodeToString(j.key.N.Bytes()),
+													E: base64.RawURLEncoding.EncodeToString(big.NewInt(int64(j.key.E)).Bytes()) })
+	}
+	json.NewEncoder(rw).Encode(jwks)
+}
+
+func (j *jwksServer) set(kids []string, fail bool, delay time.Duration) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	j.kids, j.fail, j.delay = kids, fail, delay
+}
+
+func newTestKeySet(t *testing.T) (*KeySet, *jwksServer) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	server := &jwksServer{kids: []string{"k1"}, key: key}
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	keySet, err := NewKeySet(context.Background(), &model.JwtConfig{JwksUrl: httpServer.URL, JwksRefresh: 3600})
+	if err != nil {
+		t.Fatalf("NewKeySet: %v", err)
+	}
+	return keySet, server
+}
+
+// About allow the next load (ignores the jwksRetryInterval of the last attempt)
+func (k *KeySet) resetAttempt() {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	k.attemptedAt = time.Time{}
+}
+
+func TestKeySetUnknownKidSingleFlight(t *testing.T) {
+	keySet, server := newTestKeySet(t)
+
+	server.set([]string{"k1", "k2"}, false, 100 * time.Millisecond)
+	keySet.resetAttempt()
+
+	var wg sync.WaitGroup
+	var failures atomic.Int32
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := keySet.Key(context.Background(), "k2"); err != nil {
+				failures.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if failures.Load() != 0 {
+		t.Errorf("failures: %d, want 0", failures.Load())
+	}
+	if loads := server.loads.Load(); loads != 2 {
+		t.Errorf("loads: %d, want 2 (initial and one reload)", loads)
+	}
+}
+
+func TestKeySetFailedLoadBackoff(t *testing.T) {
+	keySet, server := newTestKeySet(t)
+
+	server.set([]string{"k1"}, true, 0)
+	keySet.resetAttempt()
+
+	for i := 0; i < 5; i++ {
+		if _, err := keySet.Key(context.Background(), "k3"); err == nil {
+			t.Fatalf("unknown kid accepted")
+		}
+	}
+	if loads := server.loads.Load(); loads != 2 {
+		t.Errorf("loads: %d, want 2 (a failed load is not retried before the backoff)", loads)
+	}
+	if _, err := keySet.Key(context.Background(), "k1"); err != nil {
+		t.Errorf("key in use after a failed load: %v", err)
+	}
+}
+
+func TestKeySetStaleDoesNotBlock(t *testing.T) {
+	keySet, server := newTestKeySet(t)
+
+	server.set([]string{"k1"}, false, 2 * time.Second)
+	keySet.mutex.Lock()
+	keySet.loadedAt = time.Now().Add(-2 * time.Hour)
+	keySet.attemptedAt = time.Time{}
+	keySet.mutex.Unlock()
+
+	start := time.Now()
+	if _, err := keySet.Key(context.Background(), "k1"); err != nil {
+		t.Fatalf("Key: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500 * time.Millisecond {
+		t.Errorf("stale key served in %v, the reload is on the request path", elapsed)
+	}
+}
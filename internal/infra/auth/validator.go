@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"fmt"
+	"time"
+	"strings"
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/erro"
+)
+
+var childLogger = log.With().Str("component","go-debit").Str("package","internal.infra.auth").Logger()
+
+// About the validator of the bearer JWT (RS256/ES256 signed by a key of the JWKS)
+type Validator struct {
+	jwtConfig	*model.JwtConfig
+	keySet		*KeySet
+	parser		*jwt.Parser
+}
+
+func NewValidator(ctx context.Context, jwtConfig *model.JwtConfig) (*Validator, error) {
+	childLogger.Info().Str("func","NewValidator").Send()
+
+	if jwtConfig.JwksFile == "" && jwtConfig.JwksUrl == "" {
+		return nil, fmt.Errorf("%w: JWT_JWKS_FILE or JWT_JWKS_URL is required", erro.ErrJwks)
+	}
+	if jwtConfig.Audience == "" {
+		return nil, fmt.Errorf("%w: JWT_AUDIENCE is required", erro.ErrInvalidToken)
+	}
+
+	keySet, err := NewKeySet(ctx, jwtConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	options := []jwt.ParserOption{	jwt.WithValidMethods([]string{"RS256", "ES256"}),
+									jwt.WithAudience(jwtConfig.Audience),
+									jwt.WithLeeway(time.Duration(jwtConfig.Leeway) * time.Second),
+									jwt.WithExpirationRequired() }
+	if jwtConfig.Issuer != "" {
+		options = append(options, jwt.WithIssuer(jwtConfig.Issuer))
+	}
+
+	return &Validator{
+		jwtConfig: jwtConfig,
+		keySet: keySet,
+		parser: jwt.NewParser(options...),
+	}, nil
+}
+
+// About validate the token (signature, exp, nbf, aud and iss) and return the identity of its claims
+func (v *Validator) Validate(ctx context.Context, tokenString string) (*model.Identity, error) {
+	claims := jwt.MapClaims{}
+	_, err := v.parser.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return v.keySet.Key(ctx, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", erro.ErrInvalidToken, err.Error())
+	}
+
+	subject, _ := claims.GetSubject()
+	if subject == "" {
+		return nil, fmt.Errorf("%w: token without sub", erro.ErrInvalidToken)
+	}
+
+	identity := model.Identity{ Subject: subject,
+								Scopes: scopes(claims),
+								Roles: roles(claims) }
+	if tenant, ok := claims[v.jwtConfig.TenantClaim].(string); ok {
+		identity.TenantID = tenant
+	}
+	if person, ok := claims[v.jwtConfig.PersonClaim].(string); ok {
+		identity.PersonID = person
+	}
+
+	return &identity, nil
+}
+
+// About the scopes of the claims, "scope" as a space separated string or "scp" as a list
+func scopes(claims jwt.MapClaims) []string {
+	if scope, ok := claims["scope"].(string); ok {
+		return strings.Fields(scope)
+	}
+
+	scopes := []string{}
+	if scp, ok := claims["scp"].([]interface{}); ok {
+		for _, s := range scp {
+			if value, ok := s.(string); ok {
+				scopes = append(scopes, value)
+			}
+		}
+	}
+	return scopes
+}
+
+// About the roles of the claims, "roles" as a list or a space separated string
+func roles(claims jwt.MapClaims) []string {
+	if role, ok := claims["roles"].(string); ok {
+		return strings.Fields(role)
+	}
+
+	roles := []string{}
+	if list, ok := claims["roles"].([]interface{}); ok {
+		for _, r := range list {
+			if value, ok := r.(string); ok {
+				roles = append(roles, value)
+			}
+		}
+	}
+	return roles
+}
+
+// About check if the identity holds the operator role (JWT_OPERATOR_ROLE), an operator is not restricted to its own accounts
+func (v *Validator) IsOperator(identity *model.Identity) bool {
+	for _, r := range identity.Roles {
+		if r == v.jwtConfig.OperatorRole {
+			return true
+		}
+	}
+	return false
+}
+
+// About check if the identity was granted the scope
+func HasScope(identity *model.Identity, scope string) bool {
+	for _, s := range identity.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
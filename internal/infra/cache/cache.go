@@ -0,0 +1,139 @@
+package cache
+
+import (
+	"sync"
+	"time"
+	"context"
+	"sync/atomic"
+
+	"github.com/rs/zerolog/log"
+	"github.com/go-debit/internal/core/model"
+)
+
+var childLogger = log.With().Str("component","go-debit").Str("package","internal.infra.cache").Logger()
+
+// About load the value of a key from its source
+type Loader func(ctx context.Context) (interface{}, error)
+
+type cacheEntry struct {
+	value		interface{}
+	expireAt	time.Time
+	refreshing	bool
+}
+
+// About an in-process ttl cache with stale-while-revalidate
+// A fresh entry is served from memory, an expired entry is still served (stale) while
+// it is reloaded in background, and only after the stale ttl the caller waits for the source
+type Cache struct {
+	mutex		sync.Mutex
+	entries		map[string]*cacheEntry
+	ttl			time.Duration
+	staleTTL	time.Duration
+	hits		atomic.Uint64
+	misses		atomic.Uint64
+	staleHits	atomic.Uint64
+}
+
+func NewCache(cacheConfig model.CacheConfig) *Cache {
+	childLogger.Info().Str("func","NewCache").Interface("cacheConfig", cacheConfig).Send()
+
+	return &Cache{
+		entries: map[string]*cacheEntry{},
+		ttl: time.Duration(cacheConfig.TTL) * time.Second,
+		staleTTL: time.Duration(cacheConfig.StaleTTL) * time.Second,
+	}
+}
+
+// About get the value of a key, calling the loader when it is missing or too old
+func (c *Cache) Get(ctx context.Context, key string, loader Loader) (interface{}, error) {
+	now := time.Now()
+
+	c.mutex.Lock()
+	entry, ok := c.entries[key]
+	if ok && now.Before(entry.expireAt) {
+		c.mutex.Unlock()
+		c.hits.Add(1)
+		return entry.value, nil
+	}
+	if ok && now.Before(entry.expireAt.Add(c.staleTTL)) {
+		refresh := !entry.refreshing
+		entry.refreshing = true
+		value := entry.value
+		c.mutex.Unlock()
+
+		c.staleHits.Add(1)
+		if refresh {
+			go c.refresh(context.WithoutCancel(ctx), key, loader)
+		}
+		return value, nil
+	}
+	c.mutex.Unlock()
+
+	c.misses.Add(1)
+	value, err := loader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.set(key, value)
+
+	return value, nil
+}
+
+// About reload a stale key in background (on error the stale value is kept)
+func (c *Cache) refresh(ctx context.Context, key string, loader Loader) {
+	value, err := loader(ctx)
+	if err != nil {
+		childLogger.Error().Err(err).Str("key", key).Msg("error refresh cache, keeping the stale value")
+
+		c.mutex.Lock()
+		if entry, ok := c.entries[key]; ok {
+			entry.refreshing = false
+		}
+		c.mutex.Unlock()
+		return
+	}
+	c.set(key, value)
+}
+
+func (c *Cache) set(key string, value interface{}) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries[key] = &cacheEntry{value: value, expireAt: time.Now().Add(c.ttl)}
+}
+
+// About remove a key, or all the keys when the key is empty
+func (c *Cache) Invalidate(key string) int {
+	childLogger.Info().Str("func","Invalidate").Str("key", key).Send()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if key == "" {
+		count := len(c.entries)
+		c.entries = map[string]*cacheEntry{}
+		return count
+	}
+	if _, ok := c.entries[key]; !ok {
+		return 0
+	}
+	delete(c.entries, key)
+
+	return 1
+}
+
+// About the counters of the cache
+func (c *Cache) Stats() model.CacheStats {
+	c.mutex.Lock()
+	entries := len(c.entries)
+	c.mutex.Unlock()
+
+	return model.CacheStats{
+		Entries: entries,
+		Hits: c.hits.Load(),
+		Misses: c.misses.Load(),
+		StaleHits: c.staleHits.Load(),
+		TTL: int(c.ttl.Seconds()),
+		StaleTTL: int(c.staleTTL.Seconds()),
+	}
+}
@@ -1,24 +1,212 @@
 package circuitbreaker
 
 import (
+    "sort"
+    "sync"
     "time"
+    "context"
+
 	"github.com/sony/gobreaker"
+	"github.com/rs/zerolog/log"
+    "github.com/go-debit/internal/core/model"
     "github.com/go-debit/internal/core/erro"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var childLogger = log.With().Str("component","go-debit").Str("package","internal.infra.circuitbreaker").Logger()
+
+const (
+    ForcedOpen      = "open"
+    ForcedClosed    = "closed"
 )
 
-func CircuitBreakerConfig() *gobreaker.CircuitBreaker {
+// About a circuit breaker and the state forced by the admin (empty when not forced)
+type circuitBreakerEntry struct {
+    breaker     *gobreaker.CircuitBreaker
+    forced      string
+}
+
+// About the long-lived circuit breakers, one per downstream service name
+type CircuitBreakerRegistry struct {
+    mutex           sync.RWMutex
+    breakers        map[string]*circuitBreakerEntry
+    configs         map[string]model.CircuitBreakerConfig
+    defaultConfig   model.CircuitBreakerConfig
+}
+
+func NewCircuitBreakerRegistry(defaultConfig model.CircuitBreakerConfig, 
+                                configs []model.CircuitBreakerConfig) *CircuitBreakerRegistry {
+    childLogger.Info().Str("func","NewCircuitBreakerRegistry").Send()
+
+    registry := CircuitBreakerRegistry{
+        breakers: map[string]*circuitBreakerEntry{},
+        configs: map[string]model.CircuitBreakerConfig{},
+        defaultConfig: defaultConfig,
+    }
+    for _, config := range configs {
+        registry.configs[config.Name] = config
+        registry.breakers[config.Name] = &circuitBreakerEntry{breaker: CircuitBreakerConfig(config)}
+    }
+
+    return &registry
+}
+
+// About get the circuit breaker of a service (created in the first use)
+func (r *CircuitBreakerRegistry) get(name string) *circuitBreakerEntry {
+    r.mutex.RLock()
+    entry, ok := r.breakers[name]
+    r.mutex.RUnlock()
+    if ok {
+        return entry
+    }
+
+    r.mutex.Lock()
+    defer r.mutex.Unlock()
+
+    if entry, ok := r.breakers[name]; ok {
+        return entry
+    }
+
+    entry = &circuitBreakerEntry{breaker: CircuitBreakerConfig(r.config(name))}
+    r.breakers[name] = entry
+
+    return entry
+}
+
+func (r *CircuitBreakerRegistry) config(name string) model.CircuitBreakerConfig {
+    config, ok := r.configs[name]
+    if !ok {
+        config = r.defaultConfig
+        config.Name = name
+    }
+    return config
+}
+
+// About execute a request through the circuit breaker of the service
+// A forced open breaker rejects the request, a forced closed breaker lets every request pass
+func (r *CircuitBreakerRegistry) Execute(name string, req func() (interface{}, error)) (interface{}, error) {
+    entry := r.get(name)
+
+    r.mutex.RLock()
+    breaker, forced := entry.breaker, entry.forced
+    r.mutex.RUnlock()
+
+    switch forced {
+    case ForcedOpen:
+        return nil, gobreaker.ErrOpenState
+    case ForcedClosed:
+        return req()
+    }
+
+    return breaker.Execute(req)
+}
+
+// About the state and counts of every circuit breaker
+func (r *CircuitBreakerRegistry) List() []model.CircuitBreakerStatus {
+    r.mutex.RLock()
+    defer r.mutex.RUnlock()
+
+    list := []model.CircuitBreakerStatus{}
+    for name, entry := range r.breakers {
+        list = append(list, status(name, entry))
+    }
+    sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+
+    return list
+}
+
+// About force the state of a circuit breaker (open, close) or reset it to a new closed breaker
+func (r *CircuitBreakerRegistry) Change(ctx context.Context, name string, action string) (*model.CircuitBreakerStatus, error) {
+    r.mutex.Lock()
+    defer r.mutex.Unlock()
+
+    entry, ok := r.breakers[name]
+    if !ok {
+        return nil, erro.ErrNotFound
+    }
+
+    from := effectiveState(entry)
+    switch action {
+    case "open":
+        entry.forced = ForcedOpen
+    case "close":
+        entry.forced = ForcedClosed
+    case "reset":
+        entry.forced = ""
+        entry.breaker = CircuitBreakerConfig(r.config(name))
+    default:
+        return nil, erro.ErrInvalidAction
+    }
+
+    RecordStateChange(ctx, name, from, effectiveState(entry), action)
+
+    res := status(name, entry)
+    return &res, nil
+}
+
+func effectiveState(entry *circuitBreakerEntry) string {
+    switch entry.forced {
+    case ForcedOpen:
+        return gobreaker.StateOpen.String()
+    case ForcedClosed:
+        return gobreaker.StateClosed.String()
+    }
+    return entry.breaker.State().String()
+}
+
+func status(name string, entry *circuitBreakerEntry) model.CircuitBreakerStatus {
+    counts := entry.breaker.Counts()
+    return model.CircuitBreakerStatus{
+        Name: name,
+        State: effectiveState(entry),
+        Forced: entry.forced,
+        Requests: counts.Requests,
+        TotalSuccesses: counts.TotalSuccesses,
+        TotalFailures: counts.TotalFailures,
+        ConsecutiveSuccesses: counts.ConsecutiveSuccesses,
+        ConsecutiveFailures: counts.ConsecutiveFailures,
+    }
+}
+
+// About log a state transition and emit it as a trace event
+func RecordStateChange(ctx context.Context, name string, from string, to string, reason string) {
+    childLogger.Warn().Str("circuit_breaker", name).Str("from", from).Str("to", to).Str("reason", reason).Msg("circuit breaker state changed")
+
+    _, span := otel.GetTracerProvider().Tracer("go-debit").Start(ctx, "circuitbreaker.StateChange")
+    defer span.End()
+
+    span.AddEvent("circuit breaker state changed", trace.WithAttributes(attribute.String("circuit_breaker.name", name),
+                                                                        attribute.String("circuit_breaker.from", from),
+                                                                        attribute.String("circuit_breaker.to", to),
+                                                                        attribute.String("circuit_breaker.reason", reason)))
+}
+
+// About the result of a call counted by the circuit breaker
+// The client errors (401, 403, 400/404) are answers of a healthy service, only the unavailability and the server errors are failures
+func isSuccessful(err error) bool {
+    switch err {
+    case nil, erro.ErrNotFound, erro.ErrBadRequest, erro.ErrUnauthorized, erro.ErrHTTPForbiden:
+        return true
+    }
+    return false
+}
+
+// About create a circuit breaker
+func CircuitBreakerConfig(config model.CircuitBreakerConfig) *gobreaker.CircuitBreaker {
     settings := gobreaker.Settings{
-                                        Name:    "server-circuit-breaker",
-                                        Timeout: 5 * time.Second,
-                                        Interval: 10 * time.Second,
-                                        IsSuccessful: func(err error) bool {
-                                            if (err == erro.ErrNotFound) || (err == nil) {
-                                                return true
-                                            } 
-                                            return false
-                                        },
+                                        Name:    config.Name,
+                                        MaxRequests: config.MaxRequests,
+                                        Timeout: time.Duration(config.Timeout) * time.Second,
+                                        Interval: time.Duration(config.Interval) * time.Second,
+                                        IsSuccessful: isSuccessful,
                                         ReadyToTrip: func(counts gobreaker.Counts) bool {
-                                            return counts.TotalFailures >= 3
+                                            return counts.TotalFailures >= config.FailureThreshold
+                                        },
+                                        OnStateChange: func(name string, from gobreaker.State, to gobreaker.State) {
+                                            RecordStateChange(context.Background(), name, from.String(), to.String(), "counts")
                                         },
     }
     return gobreaker.NewCircuitBreaker(settings)
@@ -0,0 +1,31 @@
+package circuitbreaker
+
+import (
+    "testing"
+
+    "github.com/go-debit/internal/core/erro"
+    "github.com/go-debit/internal/core/model"
+)
+
+func TestClientErrorsDoNotOpenTheBreaker(t *testing.T) {
+    registry := NewCircuitBreakerRegistry(model.CircuitBreakerConfig{MaxRequests: 1, Interval: 10, Timeout: 5, FailureThreshold: 2}, nil)
+
+    for _, err := range []error{erro.ErrNotFound, erro.ErrBadRequest, erro.ErrUnauthorized, erro.ErrHTTPForbiden} {
+        registry.Execute("go-account", func() (interface{}, error) { return nil, err })
+    }
+    if state := registry.get("go-account").breaker.State().String(); state != "closed" {
+        t.Fatalf("state after client errors: %s, want closed", state)
+    }
+
+    for i := 0; i < 2; i++ {
+        registry.Execute("go-account", func() (interface{}, error) { return nil, erro.ErrServer })
+    }
+    if state := registry.get("go-account").breaker.State().String(); state != "open" {
+        t.Fatalf("state after server errors: %s, want open", state)
+    }
+
+    // The postings have their own breaker
+    if state := registry.get("go-account-posting").breaker.State().String(); state != "closed" {
+        t.Errorf("state of the posting breaker: %s, want closed", state)
+    }
+}
@@ -0,0 +1,44 @@
+package configuration
+
+import(
+	"os"
+	"fmt"
+	"strconv"
+
+	"github.com/joho/godotenv"
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/erro"
+)
+
+func GetBatchEnv() model.BatchConfig {
+	childLogger.Info().Str("func","GetBatchEnv").Send()
+
+	err := godotenv.Load(".env")
+	if err != nil {
+		childLogger.Info().Err(err).Send()
+	}
+
+	var batchConfig model.BatchConfig
+	batchConfig.Concurrency = 5
+	batchConfig.MaxItems = 1000
+
+	if os.Getenv("BATCH_CONCURRENCY") !=  "" {
+		intVar, _ := strconv.Atoi(os.Getenv("BATCH_CONCURRENCY"))
+		batchConfig.Concurrency = intVar
+	}
+	if os.Getenv("BATCH_MAX_ITEMS") !=  "" {
+		intVar, _ := strconv.Atoi(os.Getenv("BATCH_MAX_ITEMS"))
+		batchConfig.MaxItems = intVar
+	}
+
+	return batchConfig
+}
+
+// About check the batch leaves connections of the pool to the other requests
+// Each debit of the batch holds a connection for its transaction, a concurrency equal to the pool would starve the service
+func ValidateBatchConfig(batchConfig *model.BatchConfig, poolMaxConns int) error {
+	if batchConfig.Concurrency < 1 || batchConfig.Concurrency >= poolMaxConns {
+		return fmt.Errorf("%w: BATCH_CONCURRENCY %d, pool max connections %d", erro.ErrBatchConcurrency, batchConfig.Concurrency, poolMaxConns)
+	}
+	return nil
+}
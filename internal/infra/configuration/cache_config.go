@@ -0,0 +1,33 @@
+package configuration
+
+import(
+	"os"
+	"strconv"
+
+	"github.com/joho/godotenv"
+	"github.com/go-debit/internal/core/model"
+)
+
+func GetCacheEnv() model.CacheConfig {
+	childLogger.Info().Str("func","GetCacheEnv").Send()
+
+	err := godotenv.Load(".env")
+	if err != nil {
+		childLogger.Info().Err(err).Send()
+	}
+
+	var cacheConfig model.CacheConfig
+	cacheConfig.TTL = 300
+	cacheConfig.StaleTTL = 3600
+
+	if os.Getenv("PAYFEE_CACHE_TTL") !=  "" {
+		intVar, _ := strconv.Atoi(os.Getenv("PAYFEE_CACHE_TTL"))
+		cacheConfig.TTL = intVar
+	}
+	if os.Getenv("PAYFEE_CACHE_STALE_TTL") !=  "" {
+		intVar, _ := strconv.Atoi(os.Getenv("PAYFEE_CACHE_STALE_TTL"))
+		cacheConfig.StaleTTL = intVar
+	}
+
+	return cacheConfig
+}
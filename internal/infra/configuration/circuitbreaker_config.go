@@ -0,0 +1,68 @@
+package configuration
+
+import(
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"github.com/go-debit/internal/core/model"
+)
+
+// About load the circuit breaker of each downstream service
+// The default is overridden per service with the service name as suffix (ex: CB_TIMEOUT_GO_PAYFEE for go-payfee)
+func GetCircuitBreakerEnv(apiService []model.ApiService) (model.CircuitBreakerConfig, []model.CircuitBreakerConfig) {
+	childLogger.Info().Str("func","GetCircuitBreakerEnv").Send()
+
+	err := godotenv.Load(".env")
+	if err != nil {
+		childLogger.Info().Err(err).Send()
+	}
+
+	var defaultConfig model.CircuitBreakerConfig
+	defaultConfig.MaxRequests = 1
+	defaultConfig.Interval = 10
+	defaultConfig.Timeout = 5
+	defaultConfig.FailureThreshold = 3
+
+	loadCircuitBreakerEnv(&defaultConfig, "")
+
+	var circuitBreakerConfig []model.CircuitBreakerConfig
+	for _, v := range apiService {
+		found := false
+		for _, c := range circuitBreakerConfig {
+			if c.Name == v.Name {
+				found = true
+			}
+		}
+		if found || v.Name == "" {
+			continue
+		}
+
+		config := defaultConfig
+		config.Name = v.Name
+		loadCircuitBreakerEnv(&config, "_" + strings.ToUpper(strings.ReplaceAll(v.Name, "-", "_")))
+		circuitBreakerConfig = append(circuitBreakerConfig, config)
+	}
+
+	return defaultConfig, circuitBreakerConfig
+}
+
+func loadCircuitBreakerEnv(config *model.CircuitBreakerConfig, suffix string) {
+	if os.Getenv("CB_MAX_REQUESTS" + suffix) !=  "" {
+		intVar, _ := strconv.Atoi(os.Getenv("CB_MAX_REQUESTS" + suffix))
+		config.MaxRequests = uint32(intVar)
+	}
+	if os.Getenv("CB_INTERVAL" + suffix) !=  "" {
+		intVar, _ := strconv.Atoi(os.Getenv("CB_INTERVAL" + suffix))
+		config.Interval = intVar
+	}
+	if os.Getenv("CB_TIMEOUT" + suffix) !=  "" {
+		intVar, _ := strconv.Atoi(os.Getenv("CB_TIMEOUT" + suffix))
+		config.Timeout = intVar
+	}
+	if os.Getenv("CB_FAILURE_THRESHOLD" + suffix) !=  "" {
+		intVar, _ := strconv.Atoi(os.Getenv("CB_FAILURE_THRESHOLD" + suffix))
+		config.FailureThreshold = uint32(intVar)
+	}
+}
@@ -76,5 +76,20 @@ func GetEndpointEnv() []model.ApiService {
 	}
 	apiService = append(apiService, apiService04)
 
+	var apiService05 model.ApiService
+	if os.Getenv("URL_SERVICE_05") !=  "" {
+		apiService05.Url = os.Getenv("URL_SERVICE_05")
+	}
+	if os.Getenv("X_APIGW_API_ID_SERVICE_05") !=  "" {
+		apiService05.Header_x_apigw_api_id = os.Getenv("X_APIGW_API_ID_SERVICE_05")
+	}
+	if os.Getenv("METHOD_SERVICE_05") !=  "" {
+		apiService05.Method = os.Getenv("METHOD_SERVICE_05")
+	}
+	if os.Getenv("NAME_SERVICE_05") !=  "" {
+		apiService05.Name = os.Getenv("NAME_SERVICE_05")
+	}
+	apiService = append(apiService, apiService05)
+
 	return apiService
 }
\ No newline at end of file
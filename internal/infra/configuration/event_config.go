@@ -0,0 +1,61 @@
+package configuration
+
+import(
+	"os"
+	"strconv"
+
+	"github.com/joho/godotenv"
+	"github.com/go-debit/internal/core/model"
+	go_core_kafka "github.com/eliezerraj/go-core/event/kafka"
+)
+
+func GetEventEnv() model.EventConfig {
+	childLogger.Info().Str("func","GetEventEnv").Send()
+
+	err := godotenv.Load(".env")
+	if err != nil {
+		childLogger.Info().Err(err).Send()
+	}
+
+	var eventConfig model.EventConfig
+	eventConfig.Publisher = "none"
+	eventConfig.Topic = "topic.debit.event"
+	eventConfig.Source = "go-debit"
+
+	if os.Getenv("EVENT_PUBLISHER") !=  "" {
+		eventConfig.Publisher = os.Getenv("EVENT_PUBLISHER")
+	}
+	if os.Getenv("EVENT_TOPIC") !=  "" {
+		eventConfig.Topic = os.Getenv("EVENT_TOPIC")
+	}
+	if os.Getenv("EVENT_SOURCE") !=  "" {
+		eventConfig.Source = os.Getenv("EVENT_SOURCE")
+	}
+
+	if eventConfig.Publisher != "kafka" {
+		return eventConfig
+	}
+
+	var kafkaConfigurations go_core_kafka.KafkaConfigurations
+	kafkaConfigurations.Username = os.Getenv("KAFKA_USER")
+	kafkaConfigurations.Password = os.Getenv("KAFKA_PASSWORD")
+	kafkaConfigurations.Protocol = os.Getenv("KAFKA_PROTOCOL")
+	kafkaConfigurations.Mechanisms = os.Getenv("KAFKA_MECHANISM")
+	kafkaConfigurations.Clientid = os.Getenv("KAFKA_CLIENT_ID")
+	kafkaConfigurations.Brokers1 = os.Getenv("KAFKA_BROKER_1")
+	kafkaConfigurations.Brokers2 = os.Getenv("KAFKA_BROKER_2")
+	kafkaConfigurations.Brokers3 = os.Getenv("KAFKA_BROKER_3")
+
+	if os.Getenv("KAFKA_PARTITION") !=  "" {
+		intVar, _ := strconv.Atoi(os.Getenv("KAFKA_PARTITION"))
+		kafkaConfigurations.Partition = intVar
+	}
+	if os.Getenv("KAFKA_REPLICATION") !=  "" {
+		intVar, _ := strconv.Atoi(os.Getenv("KAFKA_REPLICATION"))
+		kafkaConfigurations.ReplicationFactor = intVar
+	}
+
+	eventConfig.KafkaConfigurations = &kafkaConfigurations
+
+	return eventConfig
+}
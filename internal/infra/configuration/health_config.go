@@ -0,0 +1,44 @@
+package configuration
+
+import(
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"github.com/go-debit/internal/core/model"
+)
+
+func GetHealthEnv() model.HealthConfig {
+	childLogger.Info().Str("func","GetHealthEnv").Send()
+
+	err := godotenv.Load(".env")
+	if err != nil {
+		childLogger.Info().Err(err).Send()
+	}
+
+	var healthConfig model.HealthConfig
+	healthConfig.Timeout = 1000
+	healthConfig.Critical = []string{"database"}
+
+	if os.Getenv("HEALTH_TIMEOUT") !=  "" {
+		intVar, _ := strconv.Atoi(os.Getenv("HEALTH_TIMEOUT"))
+		healthConfig.Timeout = intVar
+	}
+	if os.Getenv("HEALTH_ACCOUNT_URL") !=  "" {
+		healthConfig.AccountUrl = os.Getenv("HEALTH_ACCOUNT_URL")
+	}
+	if os.Getenv("HEALTH_PAYFEE_URL") !=  "" {
+		healthConfig.PayFeeUrl = os.Getenv("HEALTH_PAYFEE_URL")
+	}
+	if os.Getenv("HEALTH_CRITICAL") !=  "" {
+		healthConfig.Critical = []string{}
+		for _, name := range strings.Split(os.Getenv("HEALTH_CRITICAL"), ",") {
+			if strings.TrimSpace(name) != "" {
+				healthConfig.Critical = append(healthConfig.Critical, strings.TrimSpace(name))
+			}
+		}
+	}
+
+	return healthConfig
+}
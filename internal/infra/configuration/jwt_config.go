@@ -0,0 +1,62 @@
+package configuration
+
+import(
+	"os"
+	"strconv"
+
+	"github.com/joho/godotenv"
+	"github.com/go-debit/internal/core/model"
+)
+
+func GetJwtEnv() model.JwtConfig {
+	childLogger.Info().Str("func","GetJwtEnv").Send()
+
+	err := godotenv.Load(".env")
+	if err != nil {
+		childLogger.Info().Err(err).Send()
+	}
+
+	var jwtConfig model.JwtConfig
+	jwtConfig.Enabled = true
+	jwtConfig.JwksRefresh = 300
+	jwtConfig.Leeway = 30
+	jwtConfig.TenantClaim = "tenant_id"
+	jwtConfig.PersonClaim = "person_id"
+	jwtConfig.OperatorRole = "operator"
+
+	if os.Getenv("JWT_ENABLED") ==  "false" {
+		jwtConfig.Enabled = false
+	}
+	if os.Getenv("JWT_JWKS_FILE") !=  "" {
+		jwtConfig.JwksFile = os.Getenv("JWT_JWKS_FILE")
+	}
+	if os.Getenv("JWT_JWKS_URL") !=  "" {
+		jwtConfig.JwksUrl = os.Getenv("JWT_JWKS_URL")
+	}
+	if os.Getenv("JWT_JWKS_REFRESH") !=  "" {
+		intVar, _ := strconv.Atoi(os.Getenv("JWT_JWKS_REFRESH"))
+		jwtConfig.JwksRefresh = intVar
+	}
+	if os.Getenv("JWT_ISSUER") !=  "" {
+		jwtConfig.Issuer = os.Getenv("JWT_ISSUER")
+	}
+	if os.Getenv("JWT_AUDIENCE") !=  "" {
+		jwtConfig.Audience = os.Getenv("JWT_AUDIENCE")
+	}
+	if os.Getenv("JWT_LEEWAY") !=  "" {
+		intVar, _ := strconv.Atoi(os.Getenv("JWT_LEEWAY"))
+		jwtConfig.Leeway = intVar
+	}
+	if os.Getenv("JWT_TENANT_CLAIM") !=  "" {
+		jwtConfig.TenantClaim = os.Getenv("JWT_TENANT_CLAIM")
+	}
+
+	if os.Getenv("JWT_PERSON_CLAIM") !=  "" {
+		jwtConfig.PersonClaim = os.Getenv("JWT_PERSON_CLAIM")
+	}
+	if os.Getenv("JWT_OPERATOR_ROLE") !=  "" {
+		jwtConfig.OperatorRole = os.Getenv("JWT_OPERATOR_ROLE")
+	}
+
+	return jwtConfig
+}
@@ -0,0 +1,51 @@
+package configuration
+
+import(
+	"os"
+	"strconv"
+
+	"github.com/joho/godotenv"
+	"github.com/shopspring/decimal"
+	"github.com/go-debit/internal/core/model"
+)
+
+// About the debit limits per account, 0 means no limit
+func GetDebitLimitEnv() model.DebitLimitConfig {
+	childLogger.Info().Str("func","GetDebitLimitEnv").Send()
+
+	err := godotenv.Load(".env")
+	if err != nil {
+		childLogger.Info().Err(err).Send()
+	}
+
+	var debitLimitConfig model.DebitLimitConfig
+	debitLimitConfig.DailyAmount = decimal.Zero
+	debitLimitConfig.MonthlyAmount = decimal.Zero
+
+	if os.Getenv("DEBIT_LIMIT_DAILY_AMOUNT") !=  "" {
+		amount, err := decimal.NewFromString(os.Getenv("DEBIT_LIMIT_DAILY_AMOUNT"))
+		if err != nil || amount.IsNegative() {
+			childLogger.Error().Str("DEBIT_LIMIT_DAILY_AMOUNT", os.Getenv("DEBIT_LIMIT_DAILY_AMOUNT")).Msg("invalid limit ignored")
+		} else {
+			debitLimitConfig.DailyAmount = amount
+		}
+	}
+	if os.Getenv("DEBIT_LIMIT_DAILY_COUNT") !=  "" {
+		intVar, _ := strconv.Atoi(os.Getenv("DEBIT_LIMIT_DAILY_COUNT"))
+		debitLimitConfig.DailyCount = intVar
+	}
+	if os.Getenv("DEBIT_LIMIT_MONTHLY_AMOUNT") !=  "" {
+		amount, err := decimal.NewFromString(os.Getenv("DEBIT_LIMIT_MONTHLY_AMOUNT"))
+		if err != nil || amount.IsNegative() {
+			childLogger.Error().Str("DEBIT_LIMIT_MONTHLY_AMOUNT", os.Getenv("DEBIT_LIMIT_MONTHLY_AMOUNT")).Msg("invalid limit ignored")
+		} else {
+			debitLimitConfig.MonthlyAmount = amount
+		}
+	}
+	if os.Getenv("DEBIT_LIMIT_MONTHLY_COUNT") !=  "" {
+		intVar, _ := strconv.Atoi(os.Getenv("DEBIT_LIMIT_MONTHLY_COUNT"))
+		debitLimitConfig.MonthlyCount = intVar
+	}
+
+	return debitLimitConfig
+}
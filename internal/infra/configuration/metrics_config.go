@@ -0,0 +1,35 @@
+package configuration
+
+import(
+	"os"
+	"strconv"
+
+	"github.com/joho/godotenv"
+	"github.com/go-debit/internal/core/model"
+)
+
+func GetMetricsEnv() model.MetricsConfig {
+	childLogger.Info().Str("func","GetMetricsEnv").Send()
+
+	err := godotenv.Load(".env")
+	if err != nil {
+		childLogger.Info().Err(err).Send()
+	}
+
+	var metricsConfig model.MetricsConfig
+	metricsConfig.Prometheus = true
+	metricsConfig.Interval = 60
+
+	if os.Getenv("METRICS_PROMETHEUS") ==  "false" {
+		metricsConfig.Prometheus = false
+	}
+	if os.Getenv("METRICS_OTLP") ==  "true" {
+		metricsConfig.Otlp = true
+	}
+	if os.Getenv("METRICS_INTERVAL") !=  "" {
+		intVar, _ := strconv.Atoi(os.Getenv("METRICS_INTERVAL"))
+		metricsConfig.Interval = intVar
+	}
+
+	return metricsConfig
+}
@@ -0,0 +1,55 @@
+package configuration
+
+import(
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"github.com/go-debit/internal/core/model"
+)
+
+// ISO 4217 minor units, the currencies not listed use 2
+var defaultCurrencyScale = map[string]int32{
+	"BRL": 2, "USD": 2, "EUR": 2, "GBP": 2, "MXN": 2, "ARS": 2, "COP": 2,
+	"JPY": 0, "KRW": 0, "CLP": 0, "PYG": 0, "VND": 0,
+	"BHD": 3, "KWD": 3, "OMR": 3, "JOD": 3, "TND": 3,
+}
+
+func GetMoneyEnv() model.MoneyConfig {
+	childLogger.Info().Str("func","GetMoneyEnv").Send()
+
+	err := godotenv.Load(".env")
+	if err != nil {
+		childLogger.Info().Err(err).Send()
+	}
+
+	var moneyConfig model.MoneyConfig
+	moneyConfig.RoundingMode = "HALF_UP"
+	moneyConfig.CurrencyScale = map[string]int32{}
+	for k, v := range defaultCurrencyScale {
+		moneyConfig.CurrencyScale[k] = v
+	}
+
+	if os.Getenv("MONEY_ROUNDING_MODE") !=  "" {
+		moneyConfig.RoundingMode = strings.ToUpper(os.Getenv("MONEY_ROUNDING_MODE"))
+	}
+	// format CUR:scale,CUR:scale (ex: JPY:0,KWD:3)
+	if os.Getenv("MONEY_CURRENCY_SCALE") !=  "" {
+		for _, v := range strings.Split(os.Getenv("MONEY_CURRENCY_SCALE"), ",") {
+			currencyScale := strings.Split(strings.TrimSpace(v), ":")
+			if len(currencyScale) != 2 {
+				childLogger.Error().Str("MONEY_CURRENCY_SCALE", v).Msg("invalid currency scale ignored")
+				continue
+			}
+			scale, err := strconv.Atoi(currencyScale[1])
+			if err != nil || scale < 0 {
+				childLogger.Error().Str("MONEY_CURRENCY_SCALE", v).Msg("invalid currency scale ignored")
+				continue
+			}
+			moneyConfig.CurrencyScale[strings.ToUpper(currencyScale[0])] = int32(scale)
+		}
+	}
+
+	return moneyConfig
+}
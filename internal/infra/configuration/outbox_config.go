@@ -0,0 +1,38 @@
+package configuration
+
+import(
+	"os"
+	"strconv"
+
+	"github.com/joho/godotenv"
+	"github.com/go-debit/internal/core/model"
+)
+
+func GetOutboxEnv() model.OutboxConfig {
+	childLogger.Info().Str("func","GetOutboxEnv").Send()
+
+	err := godotenv.Load(".env")
+	if err != nil {
+		childLogger.Info().Err(err).Send()
+	}
+
+	var outboxConfig model.OutboxConfig
+	outboxConfig.PollInterval = 2
+	outboxConfig.BatchSize = 20
+	outboxConfig.MaxAttempts = 10
+
+	if os.Getenv("OUTBOX_POLL_INTERVAL") !=  "" {
+		intVar, _ := strconv.Atoi(os.Getenv("OUTBOX_POLL_INTERVAL"))
+		outboxConfig.PollInterval = intVar
+	}
+	if os.Getenv("OUTBOX_BATCH_SIZE") !=  "" {
+		intVar, _ := strconv.Atoi(os.Getenv("OUTBOX_BATCH_SIZE"))
+		outboxConfig.BatchSize = intVar
+	}
+	if os.Getenv("OUTBOX_MAX_ATTEMPTS") !=  "" {
+		intVar, _ := strconv.Atoi(os.Getenv("OUTBOX_MAX_ATTEMPTS"))
+		outboxConfig.MaxAttempts = intVar
+	}
+
+	return outboxConfig
+}
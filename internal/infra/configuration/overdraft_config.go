@@ -0,0 +1,57 @@
+package configuration
+
+import(
+	"os"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"github.com/shopspring/decimal"
+	"github.com/go-debit/internal/core/model"
+)
+
+func GetOverdraftEnv() model.OverdraftConfig {
+	childLogger.Info().Str("func","GetOverdraftEnv").Send()
+
+	err := godotenv.Load(".env")
+	if err != nil {
+		childLogger.Info().Err(err).Send()
+	}
+
+	var overdraftConfig model.OverdraftConfig
+	overdraftConfig.Default = model.OverdraftPolicy{CheckFunds: true, Limit: decimal.Zero}
+	overdraftConfig.Tenant = map[string]model.OverdraftPolicy{}
+
+	if os.Getenv("OVERDRAFT_CHECK_FUNDS") ==  "false" {
+		overdraftConfig.Default.CheckFunds = false
+	}
+	if os.Getenv("OVERDRAFT_LIMIT") !=  "" {
+		limit, err := decimal.NewFromString(os.Getenv("OVERDRAFT_LIMIT"))
+		if err != nil || limit.IsNegative() {
+			childLogger.Error().Str("OVERDRAFT_LIMIT", os.Getenv("OVERDRAFT_LIMIT")).Msg("invalid overdraft limit ignored")
+		} else {
+			overdraftConfig.Default.Limit = limit
+		}
+	}
+	// format TENANT:limit,TENANT:off (ex: TENANT-1:500,TENANT-2:off), off means no funds check
+	if os.Getenv("OVERDRAFT_TENANT_POLICY") !=  "" {
+		for _, v := range strings.Split(os.Getenv("OVERDRAFT_TENANT_POLICY"), ",") {
+			tenantPolicy := strings.Split(strings.TrimSpace(v), ":")
+			if len(tenantPolicy) != 2 {
+				childLogger.Error().Str("OVERDRAFT_TENANT_POLICY", v).Msg("invalid tenant policy ignored")
+				continue
+			}
+			if strings.ToLower(tenantPolicy[1]) == "off" {
+				overdraftConfig.Tenant[tenantPolicy[0]] = model.OverdraftPolicy{CheckFunds: false, Limit: decimal.Zero}
+				continue
+			}
+			limit, err := decimal.NewFromString(tenantPolicy[1])
+			if err != nil || limit.IsNegative() {
+				childLogger.Error().Str("OVERDRAFT_TENANT_POLICY", v).Msg("invalid tenant policy ignored")
+				continue
+			}
+			overdraftConfig.Tenant[tenantPolicy[0]] = model.OverdraftPolicy{CheckFunds: true, Limit: limit}
+		}
+	}
+
+	return overdraftConfig
+}
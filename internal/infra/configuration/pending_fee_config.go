@@ -0,0 +1,38 @@
+package configuration
+
+import(
+	"os"
+	"strconv"
+
+	"github.com/joho/godotenv"
+	"github.com/go-debit/internal/core/model"
+)
+
+func GetPendingFeeEnv() model.PendingFeeConfig {
+	childLogger.Info().Str("func","GetPendingFeeEnv").Send()
+
+	err := godotenv.Load(".env")
+	if err != nil {
+		childLogger.Info().Err(err).Send()
+	}
+
+	var pendingFeeConfig model.PendingFeeConfig
+	pendingFeeConfig.PollInterval = 30
+	pendingFeeConfig.BatchSize = 20
+	pendingFeeConfig.MaxAttempts = 20
+
+	if os.Getenv("PENDING_FEE_POLL_INTERVAL") !=  "" {
+		intVar, _ := strconv.Atoi(os.Getenv("PENDING_FEE_POLL_INTERVAL"))
+		pendingFeeConfig.PollInterval = intVar
+	}
+	if os.Getenv("PENDING_FEE_BATCH_SIZE") !=  "" {
+		intVar, _ := strconv.Atoi(os.Getenv("PENDING_FEE_BATCH_SIZE"))
+		pendingFeeConfig.BatchSize = intVar
+	}
+	if os.Getenv("PENDING_FEE_MAX_ATTEMPTS") !=  "" {
+		intVar, _ := strconv.Atoi(os.Getenv("PENDING_FEE_MAX_ATTEMPTS"))
+		pendingFeeConfig.MaxAttempts = intVar
+	}
+
+	return pendingFeeConfig
+}
@@ -85,6 +85,11 @@ func GetInfoPod() (	model.InfoPod, model.Server) {
 		intVar, _ := strconv.Atoi(os.Getenv("PORT"))
 		server.Port = intVar
 	}
+	server.GrpcPort = 50052
+	if os.Getenv("GRPC_PORT") !=  "" {
+		intVar, _ := strconv.Atoi(os.Getenv("GRPC_PORT"))
+		server.GrpcPort = intVar
+	}
 
 	return infoPod, server
 }
@@ -0,0 +1,26 @@
+package configuration
+
+import(
+	"os"
+
+	"github.com/joho/godotenv"
+	"github.com/go-debit/internal/core/model"
+)
+
+func GetTenantEnv() model.TenantConfig {
+	childLogger.Info().Str("func","GetTenantEnv").Send()
+
+	err := godotenv.Load(".env")
+	if err != nil {
+		childLogger.Info().Err(err).Send()
+	}
+
+	var tenantConfig model.TenantConfig
+	tenantConfig.Header = "X-Tenant-Id"
+
+	if os.Getenv("TENANT_HEADER") !=  "" {
+		tenantConfig.Header = os.Getenv("TENANT_HEADER")
+	}
+
+	return tenantConfig
+}
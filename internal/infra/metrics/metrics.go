@@ -0,0 +1,164 @@
+package metrics
+
+import (
+	"time"
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/erro"
+	"github.com/go-debit/internal/infra/circuitbreaker"
+)
+
+var childLogger = log.With().Str("component","go-debit").Str("package","internal.infra.metrics").Logger()
+
+// The instruments are created from the global meter, they record in the meter provider set by the http server
+// (before it is set the measures are dropped)
+var meter = otel.Meter("go-debit")
+
+var (
+	debitCreated		metric.Int64Counter
+	debitAmount			metric.Float64Histogram
+	feeAmount			metric.Float64Histogram
+	downstreamDuration	metric.Float64Histogram
+)
+
+func init() {
+	var err error
+
+	debitCreated, err = meter.Int64Counter("debit.created",
+											metric.WithDescription("debits created"),
+											metric.WithUnit("{debit}"))
+	logError(err)
+
+	debitAmount, err = meter.Float64Histogram("debit.amount",
+											metric.WithDescription("amount of the debits created (absolute value) by currency"),
+											metric.WithUnit("{currency}"))
+	logError(err)
+
+	feeAmount, err = meter.Float64Histogram("debit.fee.amount",
+											metric.WithDescription("amount of the fees charged by type_fee"),
+											metric.WithUnit("{currency}"))
+	logError(err)
+
+	downstreamDuration, err = meter.Float64Histogram("downstream.duration",
+											metric.WithDescription("duration of the calls to the downstream services by service name"),
+											metric.WithUnit("s"),
+											metric.WithExplicitBucketBoundaries(0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30))
+	logError(err)
+}
+
+func logError(err error) {
+	if err != nil {
+		childLogger.Error().Err(err).Msg("error create instrument")
+	}
+}
+
+// About record a debit created (after the commit)
+func RecordDebit(ctx context.Context, debit *model.AccountStatement) {
+	debitCreated.Add(ctx, 1, metric.WithAttributes(	attribute.String("currency", debit.Currency),
+													attribute.String("fee_status", debit.FeeStatus)))
+	debitAmount.Record(ctx, debit.Amount.Abs().InexactFloat64(), metric.WithAttributes(attribute.String("currency", debit.Currency)))
+}
+
+// About record the fees charged over a statement (after the commit)
+func RecordFees(ctx context.Context, accountStatementFees []model.AccountStatementFee) {
+	for _, accountStatementFee := range accountStatementFees {
+		feeAmount.Record(ctx, accountStatementFee.Amount.Abs().InexactFloat64(), metric.WithAttributes(
+													attribute.String("type_fee", accountStatementFee.TypeFee),
+													attribute.String("currency", accountStatementFee.Currency)))
+	}
+}
+
+// About record a call to a downstream service (the outcome is ok, the erro value of the status code or error)
+func RecordDownstreamCall(ctx context.Context, service string, start time.Time, err error) {
+	outcome := "ok"
+	switch err {
+	case nil:
+	case erro.ErrNotFound, erro.ErrUnauthorized, erro.ErrHTTPForbiden, erro.ErrServiceUnavailable, erro.ErrServer:
+		outcome = err.Error()
+	default:
+		outcome = "error"
+	}
+	downstreamDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(	attribute.String("service", service),
+																						attribute.String("outcome", outcome)))
+}
+
+// About observe the state of the circuit breakers (0 closed, 1 half-open, 2 open)
+func RegisterCircuitBreakers(circuitBreakers *circuitbreaker.CircuitBreakerRegistry) error {
+	childLogger.Info().Str("func","RegisterCircuitBreakers").Send()
+
+	state, err := meter.Int64ObservableGauge("circuit_breaker.state",
+											metric.WithDescription("state of the circuit breaker (0 closed, 1 half-open, 2 open)"))
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(ctx context.Context, observer metric.Observer) error {
+		for _, circuitBreakerStatus := range circuitBreakers.List() {
+			value := int64(0)
+			switch circuitBreakerStatus.State {
+			case "half-open":
+				value = 1
+			case "open":
+				value = 2
+			}
+			observer.ObserveInt64(state, value, metric.WithAttributes(attribute.String("name", circuitBreakerStatus.Name)))
+		}
+		return nil
+	}, state)
+
+	return err
+}
+
+// About observe the stats of the pgx pool (the acquires that waited for a connection show the pool exhausted)
+func RegisterDatabasePool(pool *pgxpool.Pool) error {
+	childLogger.Info().Str("func","RegisterDatabasePool").Send()
+
+	connections, err := meter.Int64ObservableGauge("db.pool.connections",
+											metric.WithDescription("connections of the pool by state (acquired, idle, constructing)"),
+											metric.WithUnit("{connection}"))
+	if err != nil {
+		return err
+	}
+	maxConnections, err := meter.Int64ObservableGauge("db.pool.connections.max",
+											metric.WithDescription("max connections of the pool"),
+											metric.WithUnit("{connection}"))
+	if err != nil {
+		return err
+	}
+	acquires, err := meter.Int64ObservableCounter("db.pool.acquires",
+											metric.WithDescription("acquires from the pool by result (immediate, waited for a connection, canceled)"),
+											metric.WithUnit("{acquire}"))
+	if err != nil {
+		return err
+	}
+	acquireDuration, err := meter.Float64ObservableCounter("db.pool.acquire.duration",
+											metric.WithDescription("total time spent to acquire a connection"),
+											metric.WithUnit("s"))
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(ctx context.Context, observer metric.Observer) error {
+		stat := pool.Stat()
+
+		observer.ObserveInt64(connections, int64(stat.AcquiredConns()), metric.WithAttributes(attribute.String("state", "acquired")))
+		observer.ObserveInt64(connections, int64(stat.IdleConns()), metric.WithAttributes(attribute.String("state", "idle")))
+		observer.ObserveInt64(connections, int64(stat.ConstructingConns()), metric.WithAttributes(attribute.String("state", "constructing")))
+		observer.ObserveInt64(maxConnections, int64(stat.MaxConns()))
+		observer.ObserveInt64(acquires, stat.AcquireCount() - stat.EmptyAcquireCount(), metric.WithAttributes(attribute.String("result", "immediate")))
+		observer.ObserveInt64(acquires, stat.EmptyAcquireCount(), metric.WithAttributes(attribute.String("result", "waited")))
+		observer.ObserveInt64(acquires, stat.CanceledAcquireCount(), metric.WithAttributes(attribute.String("result", "canceled")))
+		observer.ObserveFloat64(acquireDuration, stat.AcquireDuration().Seconds())
+		return nil
+	}, connections, maxConnections, acquires, acquireDuration)
+
+	return err
+}
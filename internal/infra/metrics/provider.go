@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"time"
+	"context"
+	"net/http"
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	otel_prometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+
+	"github.com/go-debit/internal/core/model"
+	go_core_observ "github.com/eliezerraj/go-core/observability"
+)
+
+// About create the meter provider with the Prometheus reader (the handler of /metrics, nil when disabled)
+// and the OTLP reader (pushed to the collector of the traces), the resource has the attributes of the traces
+func NewMeterProvider(	ctx context.Context,
+						metricsConfig *model.MetricsConfig,
+						configOTEL *go_core_observ.ConfigOTEL,
+						infoTrace *go_core_observ.InfoTrace) (*sdkmetric.MeterProvider, http.Handler, error) {
+	childLogger.Info().Str("func","NewMeterProvider").Send()
+
+	resources, err := resource.New(ctx, resource.WithAttributes(
+		attribute.String("service.name", infoTrace.PodName),
+		attribute.String("service.version", infoTrace.PodVersion),
+		attribute.String("account", infoTrace.AccountID),
+		attribute.String("service.type", infoTrace.ServiceType),
+		attribute.String("env", infoTrace.Env),
+	))
+	if err != nil {
+		return nil, nil, errors.New(err.Error())
+	}
+
+	options := []sdkmetric.Option{sdkmetric.WithResource(resources)}
+	var handler http.Handler
+
+	if metricsConfig.Prometheus {
+		// a registry of the service, with the go runtime and process collectors
+		registry := prometheus.NewRegistry()
+		registry.MustRegister(	collectors.NewGoCollector(),
+								collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+
+		exporter, err := otel_prometheus.New(otel_prometheus.WithRegisterer(registry))
+		if err != nil {
+			return nil, nil, errors.New(err.Error())
+		}
+		options = append(options, sdkmetric.WithReader(exporter))
+		handler = promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+	}
+
+	if metricsConfig.Otlp {
+		exporter, err := otlpmetricgrpc.New(ctx,
+											otlpmetricgrpc.WithInsecure(),
+											otlpmetricgrpc.WithEndpoint(configOTEL.OtelExportEndpoint))
+		if err != nil {
+			return nil, nil, errors.New(err.Error())
+		}
+		options = append(options, sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter,
+											sdkmetric.WithInterval(time.Duration(metricsConfig.Interval) * time.Second))))
+	}
+
+	return sdkmetric.NewMeterProvider(options...), handler, nil
+}
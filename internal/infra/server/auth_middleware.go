@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/go-debit/internal/infra/auth"
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/erro"
+	"github.com/eliezerraj/go-core/coreJson"
+)
+
+// About the scopes granted by the JWT to the routes
+const (
+	ScopeDebitRead	= "debit:read"
+	ScopeDebitWrite	= "debit:write"
+)
+
+// About validate the bearer JWT of the request and check the scope of the route
+// The identity of the token is set in the ctx, without validator (JWT_ENABLED=false) the request goes through
+func authMiddleware(validator *auth.Validator, scope string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			if validator == nil {
+				next.ServeHTTP(rw, req)
+				return
+			}
+
+			tokenString, found := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+			if !found || strings.TrimSpace(tokenString) == "" {
+				writeAuthError(rw, erro.ErrUnauthorized, http.StatusUnauthorized)
+				return
+			}
+
+			identity, err := validator.Validate(req.Context(), strings.TrimSpace(tokenString))
+			if err != nil {
+				childLogger.Error().Err(err).Interface("trace-resquest-id", req.Context().Value("trace-request-id")).Str("path", req.URL.Path).Msg("invalid token")
+				writeAuthError(rw, erro.ErrUnauthorized, http.StatusUnauthorized)
+				return
+			}
+			if !auth.HasScope(identity, scope) {
+				childLogger.Error().Interface("trace-resquest-id", req.Context().Value("trace-request-id")).Str("subject", identity.Subject).Str("scope", scope).Msg("token without scope")
+				writeAuthError(rw, erro.ErrHTTPForbiden, http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(rw, req.WithContext(identityContext(req.Context(), validator, identity)))
+		})
+	}
+}
+
+// About set the identity of the token in the ctx (subject, person-id, operator and the tenant when the token has the tenant claim)
+func identityContext(ctx context.Context, validator *auth.Validator, identity *model.Identity) context.Context {
+	ctx = context.WithValue(ctx, "subject", identity.Subject)
+	ctx = context.WithValue(ctx, "person-id", identity.PersonID)
+	ctx = context.WithValue(ctx, "operator", validator.IsOperator(identity))
+	if identity.TenantID != "" {
+		ctx = context.WithValue(ctx, "tenant-id", identity.TenantID)
+	}
+	return ctx
+}
+
+func writeAuthError(rw http.ResponseWriter, err error, statusCode int) {
+	if statusCode == http.StatusUnauthorized {
+		rw.Header().Set("WWW-Authenticate", `Bearer realm="go-debit"`)
+	}
+
+	var core_apiError coreJson.APIError
+	core_apiError = core_apiError.NewAPIError(err, statusCode)
+	core_json.WriteJSON(rw, statusCode, core_apiError)
+}
@@ -0,0 +1,150 @@
+package server
+
+import (
+	"net"
+	"context"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/reflection"
+	grpc_health "google.golang.org/grpc/health/grpc_health_v1"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/erro"
+	"github.com/go-debit/internal/infra/auth"
+	"github.com/go-debit/internal/adapter/grpc/proto"
+	adapter_grpc "github.com/go-debit/internal/adapter/grpc"
+)
+
+type GrpcServer struct {
+	httpServer		*model.Server
+	tenantConfig	*model.TenantConfig
+	jwtValidator	*auth.Validator
+}
+
+// About create a new grpc server
+func NewGrpcAppServer(httpServer *model.Server, tenantConfig *model.TenantConfig, jwtValidator *auth.Validator) GrpcServer {
+	childLogger.Info().Str("func","NewGrpcAppServer").Send()
+
+	return GrpcServer{httpServer: httpServer, tenantConfig: tenantConfig, jwtValidator: jwtValidator}
+}
+
+// About set the trace-request-id of the ctx from the x-request-id metadata (like the http middleware)
+func requestIdInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	header_req_str := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get("x-request-id")) > 0 {
+		header_req_str = md.Get("x-request-id")[0]
+	}
+	ctx = context.WithValue(ctx, "trace-request-id", header_req_str)
+
+	return handler(ctx, req)
+}
+
+// About validate the bearer JWT of the authorization metadata (like the http auth middleware)
+// AddDebit requires the debit:write scope, the other calls the debit:read scope
+func authInterceptor(validator *auth.Validator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if validator == nil || strings.HasPrefix(info.FullMethod, "/grpc.health.") {
+			return handler(ctx, req)
+		}
+
+		tokenString := ""
+		if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get("authorization")) > 0 {
+			tokenString, _ = strings.CutPrefix(md.Get("authorization")[0], "Bearer ")
+		}
+		if strings.TrimSpace(tokenString) == "" {
+			return nil, status.Error(codes.Unauthenticated, erro.ErrUnauthorized.Error())
+		}
+
+		identity, err := validator.Validate(ctx, strings.TrimSpace(tokenString))
+		if err != nil {
+			childLogger.Error().Err(err).Interface("trace-resquest-id", ctx.Value("trace-request-id")).Str("method", info.FullMethod).Msg("invalid token")
+			return nil, status.Error(codes.Unauthenticated, erro.ErrUnauthorized.Error())
+		}
+
+		scope := ScopeDebitRead
+		if info.FullMethod == proto.DebitService_AddDebit_FullMethodName {
+			scope = ScopeDebitWrite
+		}
+		if !auth.HasScope(identity, scope) {
+			childLogger.Error().Interface("trace-resquest-id", ctx.Value("trace-request-id")).Str("subject", identity.Subject).Str("scope", scope).Msg("token without scope")
+			return nil, status.Error(codes.PermissionDenied, erro.ErrHTTPForbiden.Error())
+		}
+
+		return handler(identityContext(ctx, validator, identity), req)
+	}
+}
+
+// About set the tenant-id of the ctx from the tenant claim of the token, or from the tenant metadata without validator (like the http tenant middleware)
+// The health check has no tenant, the other calls without tenant are rejected with PermissionDenied
+func tenantInterceptor(tenantConfig *model.TenantConfig, validator *auth.Validator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if strings.HasPrefix(info.FullMethod, "/grpc.health.") {
+			return handler(ctx, req)
+		}
+
+		tenant := ""
+		if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get(tenantConfig.Header)) > 0 {
+			tenant = strings.TrimSpace(md.Get(tenantConfig.Header)[0])
+		}
+		if validator != nil {
+			tokenTenant, _ := ctx.Value("tenant-id").(string)
+			if tokenTenant == "" {
+				childLogger.Error().Interface("trace-resquest-id", ctx.Value("trace-request-id")).Str("method", info.FullMethod).Msg("token without tenant claim")
+				return nil, status.Error(codes.PermissionDenied, erro.ErrHTTPForbiden.Error())
+			}
+			if tenant != "" && tenant != tokenTenant {
+				childLogger.Error().Interface("trace-resquest-id", ctx.Value("trace-request-id")).Str("method", info.FullMethod).Msg("tenant metadata differs from the token tenant")
+				return nil, status.Error(codes.PermissionDenied, erro.ErrHTTPForbiden.Error())
+			}
+			tenant = tokenTenant
+		}
+		if tenant == "" {
+			childLogger.Error().Interface("trace-resquest-id", ctx.Value("trace-request-id")).Str("method", info.FullMethod).Msg("request without tenant")
+			return nil, status.Error(codes.PermissionDenied, erro.ErrHTTPForbiden.Error())
+		}
+		ctx = context.WithValue(ctx, "tenant-id", tenant)
+
+		return handler(ctx, req)
+	}
+}
+
+// About start grpc server until the ctx is done
+func (g GrpcServer) StartGrpcAppServer(ctx context.Context, grpcHandler *adapter_grpc.GrpcHandler) {
+	childLogger.Info().Str("func","StartGrpcAppServer").Send()
+
+	listener, err := net.Listen("tcp", ":" + strconv.Itoa(g.httpServer.GrpcPort))
+	if err != nil {
+		childLogger.Error().Err(err).Msg("error open grpc port !!!")
+		return
+	}
+
+	srv := grpc.NewServer(	grpc.StatsHandler(otelgrpc.NewServerHandler()),
+							grpc.ChainUnaryInterceptor(	requestIdInterceptor,
+														authInterceptor(g.jwtValidator),
+														tenantInterceptor(g.tenantConfig, g.jwtValidator)))
+
+	proto.RegisterDebitServiceServer(srv, grpcHandler)
+	grpc_health.RegisterHealthServer(srv, health.NewServer())
+	reflection.Register(srv)
+
+	childLogger.Info().Str("Service Grpc Port", strconv.Itoa(g.httpServer.GrpcPort)).Send()
+
+	go func() {
+		<-ctx.Done()
+		childLogger.Info().Msg("stopping grpc server !!!")
+		srv.GracefulStop()
+	}()
+
+	err = srv.Serve(listener)
+	if err != nil {
+		childLogger.Error().Err(err).Msg("canceling grpc server !!!")
+	}
+}
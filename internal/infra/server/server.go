@@ -12,6 +12,8 @@ import (
 
 	"github.com/go-debit/internal/adapter/api"
 	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/infra/auth"
+	"github.com/go-debit/internal/infra/metrics"
 	go_core_observ "github.com/eliezerraj/go-core/observability"  
 
 	"github.com/gorilla/mux"
@@ -32,13 +34,15 @@ var infoTrace go_core_observ.InfoTrace
 
 type HttpServer struct {
 	httpServer	*model.Server
+	jwtValidator	*auth.Validator
 }
 
 // About create a new http server
-func NewHttpAppServer(httpServer *model.Server) HttpServer {
+func NewHttpAppServer(httpServer *model.Server, jwtValidator *auth.Validator) HttpServer {
 	childLogger.Info().Str("func","NewHttpAppServer").Send()
 
-	return HttpServer{httpServer: httpServer }
+	return HttpServer{	httpServer: httpServer,
+						jwtValidator: jwtValidator }
 }
 
 // About start http server
@@ -61,6 +65,23 @@ func (h HttpServer) StartHttpAppServer(	ctx context.Context,
 	otel.SetTextMapPropagator(xray.Propagator{})
 	otel.SetTracerProvider(tp)
 
+	// metrics (Prometheus /metrics and/or OTLP)
+	mp, metricsHandler, err := metrics.NewMeterProvider(ctx,
+														appServer.MetricsConfig,
+														appServer.ConfigOTEL,
+														&infoTrace)
+	if err != nil {
+		childLogger.Error().Err(err).Msg("error create meter provider, metrics disabled")
+	} else {
+		otel.SetMeterProvider(mp)
+		defer func() {
+			err := mp.Shutdown(ctx)
+			if err != nil{
+				childLogger.Info().Err(err).Send()
+			}
+		}()
+	}
+
 	// handle
 	defer func() { 
 		err := tp.Shutdown(ctx)
@@ -86,27 +107,99 @@ func (h HttpServer) StartHttpAppServer(	ctx context.Context,
 	live := myRouter.Methods(http.MethodGet, http.MethodOptions).Subrouter()
     live.HandleFunc("/live", httpRouters.Live)
 
+	if metricsHandler != nil {
+		metricsRouter := myRouter.Methods(http.MethodGet, http.MethodOptions).Subrouter()
+		metricsRouter.Handle("/metrics", metricsHandler)
+	}
+
 	header := myRouter.Methods(http.MethodGet, http.MethodOptions).Subrouter()
     header.HandleFunc("/header", httpRouters.Header)
 
 	myRouter.HandleFunc("/info", func(rw http.ResponseWriter, req *http.Request) {
 		childLogger.Info().Str("HandleFunc","/info").Send()
 
+		info := *appServer
+		payFeeCache := httpRouters.PayFeeCacheStats()
+		info.PayFeeCache = &payFeeCache
+
 		rw.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(rw).Encode(appServer)
+		json.NewEncoder(rw).Encode(info)
 	})
 	
+	// the routes of the tenant data
+	// the bearer JWT is validated before the tenant, with JWT the tenant is the tenant claim of the token
+	tenant := tenantMiddleware(appServer.TenantConfig, h.jwtValidator)
+	authRead := authMiddleware(h.jwtValidator, ScopeDebitRead)
+	authWrite := authMiddleware(h.jwtValidator, ScopeDebitWrite)
+
 	addDebit := myRouter.Methods(http.MethodPost, http.MethodOptions).Subrouter()
 	addDebit.HandleFunc("/add", core_middleware.MiddleWareErrorHandler(httpRouters.AddDebit))		
 	addDebit.Use(otelmux.Middleware("go-debit"))
+	addDebit.Use(authWrite)
+	addDebit.Use(tenant)
+
+	addDebitBatch := myRouter.Methods(http.MethodPost, http.MethodOptions).Subrouter()
+	addDebitBatch.HandleFunc("/add/batch", core_middleware.MiddleWareErrorHandler(httpRouters.AddDebitBatch))		
+	addDebitBatch.Use(otelmux.Middleware("go-debit"))
+	addDebitBatch.Use(authWrite)
+	addDebitBatch.Use(tenant)
 
 	listDebit := myRouter.Methods(http.MethodGet, http.MethodOptions).Subrouter()
 	listDebit.HandleFunc("/list/{id}", core_middleware.MiddleWareErrorHandler(httpRouters.ListDebit))		
 	listDebit.Use(otelmux.Middleware("go-debit"))
+	listDebit.Use(authRead)
+	listDebit.Use(tenant)
 
 	listDebitDate := myRouter.Methods(http.MethodGet, http.MethodOptions).Subrouter()
 	listDebitDate.HandleFunc("/listPerDate", core_middleware.MiddleWareErrorHandler(httpRouters.ListDebitPerDate))		
 	listDebitDate.Use(otelmux.Middleware("go-debit"))
+	listDebitDate.Use(authRead)
+	listDebitDate.Use(tenant)
+
+	getDebitLimit := myRouter.Methods(http.MethodGet, http.MethodOptions).Subrouter()
+	getDebitLimit.HandleFunc("/limits/{account_id}", core_middleware.MiddleWareErrorHandler(httpRouters.GetDebitLimit))		
+	getDebitLimit.Use(otelmux.Middleware("go-debit"))
+	getDebitLimit.Use(authRead)
+	getDebitLimit.Use(tenant)
+
+	reverseDebit := myRouter.Methods(http.MethodPost, http.MethodOptions).Subrouter()
+	reverseDebit.HandleFunc("/reverse/{transaction_id}", core_middleware.MiddleWareErrorHandler(httpRouters.ReverseDebit))		
+	reverseDebit.Use(otelmux.Middleware("go-debit"))
+	reverseDebit.Use(authWrite)
+	reverseDebit.Use(tenant)
+
+	searchStatement := myRouter.Methods(http.MethodGet, http.MethodOptions).Subrouter()
+	searchStatement.HandleFunc("/statements/search", core_middleware.MiddleWareErrorHandler(httpRouters.SearchStatement))		
+	searchStatement.Use(otelmux.Middleware("go-debit"))
+	searchStatement.Use(authRead)
+	searchStatement.Use(tenant)
+
+	exportStatement := myRouter.Methods(http.MethodGet, http.MethodOptions).Subrouter()
+	exportStatement.HandleFunc("/statements/{account_id}/export", core_middleware.MiddleWareErrorHandler(httpRouters.ExportStatement))		
+	exportStatement.Use(otelmux.Middleware("go-debit"))
+	exportStatement.Use(authRead)
+	exportStatement.Use(tenant)
+
+	camt053Statement := myRouter.Methods(http.MethodGet, http.MethodOptions).Subrouter()
+	camt053Statement.HandleFunc("/statements/{account_id}/camt053", core_middleware.MiddleWareErrorHandler(httpRouters.Camt053Statement))		
+	camt053Statement.Use(otelmux.Middleware("go-debit"))
+	camt053Statement.Use(authRead)
+	camt053Statement.Use(tenant)
+
+	listCircuitBreaker := myRouter.Methods(http.MethodGet, http.MethodOptions).Subrouter()
+	listCircuitBreaker.HandleFunc("/admin/circuit-breakers", core_middleware.MiddleWareErrorHandler(httpRouters.ListCircuitBreaker))		
+	listCircuitBreaker.Use(otelmux.Middleware("go-debit"))
+	listCircuitBreaker.Use(authRead)
+
+	changeCircuitBreaker := myRouter.Methods(http.MethodPost, http.MethodOptions).Subrouter()
+	changeCircuitBreaker.HandleFunc("/admin/circuit-breakers/{name}/{action:open|close|reset}", core_middleware.MiddleWareErrorHandler(httpRouters.ChangeCircuitBreaker))		
+	changeCircuitBreaker.Use(otelmux.Middleware("go-debit"))
+	changeCircuitBreaker.Use(authWrite)
+
+	invalidatePayFeeCache := myRouter.Methods(http.MethodPost, http.MethodOptions).Subrouter()
+	invalidatePayFeeCache.HandleFunc("/admin/payfee-cache/invalidate", core_middleware.MiddleWareErrorHandler(httpRouters.InvalidatePayFeeCache))		
+	invalidatePayFeeCache.Use(otelmux.Middleware("go-debit"))
+	invalidatePayFeeCache.Use(authWrite)
 
 	// setup http server	
 	srv := http.Server{
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/go-debit/internal/infra/auth"
+	"github.com/go-debit/internal/core/model"
+	"github.com/go-debit/internal/core/erro"
+	"github.com/eliezerraj/go-core/coreJson"
+)
+
+var core_json coreJson.CoreJson
+
+// About set the tenant-id of the ctx, all the queries of the request are scoped by this tenant
+// With JWT the tenant is the tenant claim of the token (a token without it is rejected with 403, the header if present must be the same tenant)
+// Without validator (JWT_ENABLED=false) the tenant comes from the header set by the gateway (TENANT_HEADER)
+func tenantMiddleware(tenantConfig *model.TenantConfig, validator *auth.Validator) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			tenant := strings.TrimSpace(req.Header.Get(tenantConfig.Header))
+			if validator != nil {
+				tokenTenant, _ := req.Context().Value("tenant-id").(string)
+				if tokenTenant == "" {
+					childLogger.Error().Interface("trace-resquest-id", req.Context().Value("trace-request-id")).Str("path", req.URL.Path).Msg("token without tenant claim")
+					writeAuthError(rw, erro.ErrHTTPForbiden, http.StatusForbidden)
+					return
+				}
+				if tenant != "" && tenant != tokenTenant {
+					childLogger.Error().Interface("trace-resquest-id", req.Context().Value("trace-request-id")).Str("path", req.URL.Path).Msg("tenant header differs from the token tenant")
+					writeAuthError(rw, erro.ErrHTTPForbiden, http.StatusForbidden)
+					return
+				}
+				tenant = tokenTenant
+			}
+			if tenant == "" {
+				childLogger.Error().Interface("trace-resquest-id", req.Context().Value("trace-request-id")).Str("path", req.URL.Path).Msg("request without tenant")
+
+				var core_apiError coreJson.APIError
+				core_apiError = core_apiError.NewAPIError(erro.ErrHTTPForbiden, http.StatusForbidden)
+				core_json.WriteJSON(rw, http.StatusForbidden, core_apiError)
+				return
+			}
+
+			ctx := context.WithValue(req.Context(), "tenant-id", tenant)
+			next.ServeHTTP(rw, req.WithContext(ctx))
+		})
+	}
+}